@@ -0,0 +1,169 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// adoptExisting takes over an object that already exists on the Silk server when adopt_existing is set. The object
+// is looked up through the Importer of the resource with the provided importID (ex. the configured name). Its current
+// attributes are read into a state of their own and the configuration is then applied to that state through the
+// normal Update function of the resource.
+//
+// adopted is false when adopt_existing is not set or the object does not exist, in which case the Create function
+// should continue and create it.
+func adoptExisting(ctx context.Context, r *schema.Resource, d *schema.ResourceData, m interface{}, objectType, importID string) (bool, diag.Diagnostics) {
+
+	if !d.Get("adopt_existing").(bool) {
+		return false, nil
+	}
+
+	// Look the object up through the Importer, which accepts the same name the object would be created with
+	lookup := r.Data(nil)
+	lookup.SetId(importID)
+	imported, err := r.Importer.StateContext(ctx, lookup, m)
+	if err != nil {
+		if notFound(err) {
+			return false, nil
+		}
+		return true, diag.FromErr(err)
+	}
+	id := imported[0].Id()
+
+	// Build the configuration from the planned values. Optional and Computed attributes that are not configured are
+	// left out so their current value is kept.
+	config := map[string]interface{}{}
+	for key, attribute := range r.Schema {
+		if !attribute.Required && !attribute.Optional {
+			continue
+		}
+
+		if attribute.Optional && attribute.Computed {
+			if value, ok := d.GetOk(key); ok {
+				config[key] = value
+			}
+			continue
+		}
+
+		config[key] = adoptConfigValue(attribute, d.Get(key), rawConfigAttribute(d, key))
+	}
+
+	// Read the current attributes of the object. The configured values are used as the starting point so the
+	// attributes that are only refreshed when Terraform manages them (see mapping_mode) are read as well.
+	existing := r.Data(nil)
+	existing.SetId(id)
+	for key, value := range config {
+		existing.Set(key, value)
+	}
+
+	diags := r.ReadContext(ctx, existing, m)
+	if diags.HasError() {
+		return true, diags
+	}
+
+	if existing.Id() == "" {
+		return true, diag.Errorf("The existing %s '%s' was removed while it was being adopted", objectType, importID)
+	}
+
+	diff, err := r.Diff(ctx, existing.State(), terraform.NewResourceConfigRaw(config), m)
+	if err != nil {
+		return true, diag.FromErr(err)
+	}
+
+	// The configuration built above has no raw value, so the raw configuration of the resource is passed along with
+	// the diff. The Update function relies on it to tell the configured attributes apart (see configuredLuns).
+	if diff != nil {
+		diff.RawConfig = d.GetRawConfig()
+	}
+
+	// Attributes that can only be changed by replacing the object are left as they are so the object is still
+	// adopted. The next plan shows them as a replacement.
+	replaceAttributes := []string{}
+	if diff != nil {
+		for key, attribute := range diff.Attributes {
+			if attribute.RequiresNew {
+				replaceAttributes = append(replaceAttributes, key)
+				delete(diff.Attributes, key)
+			}
+		}
+	}
+	sort.Strings(replaceAttributes)
+
+	// Reconcile the existing object with the configuration through the Update function
+	if !diff.Empty() {
+		_, applyDiags := r.Apply(ctx, existing.State(), diff, m)
+		diags = append(diags, applyDiags...)
+		if diags.HasError() {
+			return true, diags
+		}
+	}
+
+	d.SetId(id)
+	diags = append(diags, r.ReadContext(ctx, d, m)...)
+
+	detail := fmt.Sprintf("adopt_existing is set to true, so the %s that already exists on the Silk server was taken over instead of being created. Its attributes were updated to match the configuration.", objectType)
+	if len(replaceAttributes) != 0 {
+		detail += fmt.Sprintf(" %s can not be changed without replacing the %s, so the next plan will replace it.", strings.Join(replaceAttributes, ", "), objectType)
+	}
+
+	return true, append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Adopted the existing %s '%s'", objectType, importID),
+		Detail:   detail,
+	})
+}
+
+// adoptConfigValue removes the Optional and Computed attributes of nested blocks (ex. the lun of a host_mapping block)
+// that are not configured so the value read from the server is kept for them. raw is the raw configuration of the
+// attribute. When it is not available, the attributes with a zero value are treated as not configured.
+func adoptConfigValue(attribute *schema.Schema, value interface{}, raw cty.Value) interface{} {
+	elem, ok := attribute.Elem.(*schema.Resource)
+	if attribute.Type != schema.TypeList || !ok {
+		return value
+	}
+
+	blocks := []interface{}{}
+	for i, block := range value.([]interface{}) {
+		configured := map[string]interface{}{}
+		for key, v := range block.(map[string]interface{}) {
+			nested := elem.Schema[key]
+			if nested.Optional && nested.Computed && !nestedConfigured(raw, i, key, v) {
+				continue
+			}
+			configured[key] = v
+		}
+		blocks = append(blocks, configured)
+	}
+
+	return blocks
+}
+
+// nestedConfigured returns true when the attribute (key) of the block at the index i of the raw configuration of a
+// list of blocks is set. When the raw configuration is not available, the attribute is set when its value (v) is not
+// the zero value.
+func nestedConfigured(raw cty.Value, i int, key string, v interface{}) bool {
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsListType() || raw.LengthInt() <= i {
+		return v != reflect.Zero(reflect.TypeOf(v)).Interface()
+	}
+
+	return !raw.Index(cty.NumberIntVal(int64(i))).GetAttr(key).IsNull()
+}
+
+// rawConfigAttribute returns the raw configuration of the attribute of the resource. A null value is returned when the
+// configuration is not available.
+func rawConfigAttribute(d *schema.ResourceData, key string) cty.Value {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute(key) {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	return config.GetAttr(key)
+}
@@ -0,0 +1,270 @@
+package silk
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAdoptConfigValue(t *testing.T) {
+
+	hostMapping := resourceSilkVolume().Schema["host_mapping"]
+	noRawConfig := cty.NullVal(cty.DynamicPseudoType)
+	rawHostMapping := func(lun cty.Value) cty.Value {
+		return cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("host1"), "lun": lun})})
+	}
+
+	testCases := []struct {
+		name      string
+		attribute *schema.Schema
+		value     interface{}
+		raw       cty.Value
+		expected  interface{}
+	}{
+		{
+			name:      "not a block",
+			attribute: resourceSilkVolume().Schema["name"],
+			value:     "TerraformTestAccVolume",
+			raw:       cty.StringVal("TerraformTestAccVolume"),
+			expected:  "TerraformTestAccVolume",
+		},
+		{
+			name:      "lun not configured",
+			attribute: hostMapping,
+			value:     []interface{}{map[string]interface{}{"name": "host1", "lun": 0}},
+			raw:       noRawConfig,
+			expected:  []interface{}{map[string]interface{}{"name": "host1"}},
+		},
+		{
+			name:      "lun configured",
+			attribute: hostMapping,
+			value:     []interface{}{map[string]interface{}{"name": "host1", "lun": 5}},
+			raw:       noRawConfig,
+			expected:  []interface{}{map[string]interface{}{"name": "host1", "lun": 5}},
+		},
+		{
+			name:      "lun 0 configured in the raw configuration",
+			attribute: hostMapping,
+			value:     []interface{}{map[string]interface{}{"name": "host1", "lun": 0}},
+			raw:       rawHostMapping(cty.NumberIntVal(0)),
+			expected:  []interface{}{map[string]interface{}{"name": "host1", "lun": 0}},
+		},
+		{
+			name:      "lun not configured in the raw configuration",
+			attribute: hostMapping,
+			value:     []interface{}{map[string]interface{}{"name": "host1", "lun": 5}},
+			raw:       rawHostMapping(cty.NullVal(cty.Number)),
+			expected:  []interface{}{map[string]interface{}{"name": "host1"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := adoptConfigValue(tc.attribute, tc.value, tc.raw)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// testRawConfig returns the raw configuration of the resource with the provided attributes set and every other
+// attribute null, as Terraform sends it to the provider
+func testRawConfig(r *schema.Resource, attributes map[string]cty.Value) cty.Value {
+	values := map[string]cty.Value{}
+	for name, attributeType := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		} else {
+			values[name] = cty.NullVal(attributeType)
+		}
+	}
+
+	return cty.ObjectVal(values)
+}
+
+// testAdoptResource prepares the resource so it can be adopted without a Silk server. Create adopts the object named
+// in the configuration, the Importer finds it with the ID 1, Read serves the existing attributes, and Update saves the
+// ResourceData it is called with to updated instead of sending requests. The size validation of the plan is skipped
+// since it reads the Volume Group.
+func testAdoptResource(r *schema.Resource, objectType string, existing map[string]interface{}, updated **schema.ResourceData) *schema.Resource {
+	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		_, diags := adoptExisting(ctx, r, d, m, objectType, d.Get("name").(string))
+		return diags
+	}
+	r.Importer = &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			d.SetId("1")
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		for key, value := range existing {
+			d.Set(key, value)
+		}
+		return nil
+	}
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		*updated = d
+		return nil
+	}
+	r.CustomizeDiff = nil
+
+	return r
+}
+
+// testAdoptCreate runs the create of the resource with the provided configuration the way Terraform does, with the
+// raw configuration attached to the diff, and returns its diagnostics
+func testAdoptCreate(t *testing.T, r *schema.Resource, config map[string]interface{}, rawConfig cty.Value) diag.Diagnostics {
+
+	diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	diff.RawConfig = rawConfig
+
+	_, diags := r.Apply(context.Background(), nil, diff, nil)
+
+	return diags
+}
+
+// TestAdoptExisting validates an existing Volume and Host are updated to match the configuration when they are
+// adopted. The LUNs of the Volume's mappings that are not configured are not requested even though the order of the
+// configured mappings differs from the one read from the Silk server, while a configured LUN of 0 is. The requests
+// the Update functions send for those changes are covered by TestVolumeMappingChanges and the acceptance tests.
+func TestAdoptExisting(t *testing.T) {
+
+	testCases := []struct {
+		name            string
+		resource        *schema.Resource
+		objectType      string
+		existing        map[string]interface{}
+		config          map[string]interface{}
+		rawConfig       map[string]cty.Value
+		expectedChanges []string
+		check           func(t *testing.T, d *schema.ResourceData)
+	}{
+		{
+			name:       "volume",
+			resource:   resourceSilkVolume(),
+			objectType: "Volume",
+			existing: map[string]interface{}{
+				"name":              "vol1",
+				"size_in_gb":        10,
+				"size":              "10GiB",
+				"volume_group_name": "vg1",
+				"description":       "old",
+				"host_mapping": []interface{}{
+					map[string]interface{}{"name": "host1", "lun": 3},
+					map[string]interface{}{"name": "host2", "lun": 4},
+				},
+			},
+			config: map[string]interface{}{
+				"name":              "vol1",
+				"size_in_gb":        20,
+				"volume_group_name": "vg1",
+				"description":       "new",
+				"adopt_existing":    true,
+				"host_mapping": []interface{}{
+					map[string]interface{}{"name": "host2"},
+					map[string]interface{}{"name": "host1", "lun": 0},
+					map[string]interface{}{"name": "host3", "lun": 7},
+				},
+			},
+			rawConfig: map[string]cty.Value{
+				"name":              cty.StringVal("vol1"),
+				"size_in_gb":        cty.NumberIntVal(20),
+				"volume_group_name": cty.StringVal("vg1"),
+				"description":       cty.StringVal("new"),
+				"adopt_existing":    cty.True,
+				"host_mapping": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("host2"), "lun": cty.NullVal(cty.Number)}),
+					cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("host1"), "lun": cty.NumberIntVal(0)}),
+					cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("host3"), "lun": cty.NumberIntVal(7)}),
+				}),
+			},
+			expectedChanges: []string{"description", "host_mapping", "size_in_gb"},
+			check: func(t *testing.T, d *schema.ResourceData) {
+				c, n := d.GetChange("host_mapping")
+				toAdd, toRemove := volumeMappingChanges(c.([]interface{}), n.([]interface{}), configuredLuns(d, "host_mapping"))
+
+				expectedToAdd := []volumeMapping{{Name: "host1", Lun: 0}, {Name: "host3", Lun: 7}}
+				if !reflect.DeepEqual(toAdd, expectedToAdd) || !reflect.DeepEqual(toRemove, []string{"host1"}) {
+					t.Errorf("expected to map %v and unmap [host1], got %v and %v", expectedToAdd, toAdd, toRemove)
+				}
+			},
+		},
+		{
+			name:       "host",
+			resource:   resourceSilkHost(),
+			objectType: "Host",
+			existing: map[string]interface{}{
+				"name":      "host1",
+				"host_type": "Linux",
+				"pwwn":      []interface{}{},
+			},
+			config: map[string]interface{}{
+				"name":           "host1",
+				"host_type":      "Windows",
+				"pwwn":           []interface{}{"20:21:22:23:45:67:89:ab"},
+				"adopt_existing": true,
+			},
+			rawConfig: map[string]cty.Value{
+				"name":           cty.StringVal("host1"),
+				"host_type":      cty.StringVal("Windows"),
+				"pwwn":           cty.ListVal([]cty.Value{cty.StringVal("20:21:22:23:45:67:89:ab")}),
+				"adopt_existing": cty.True,
+			},
+			expectedChanges: []string{"host_type", "pwwn"},
+			check: func(t *testing.T, d *schema.ResourceData) {
+				if hostType := d.Get("host_type").(string); hostType != "Windows" {
+					t.Errorf("expected the host_type Windows, got %s", hostType)
+				}
+				if pwwn := d.Get("pwwn").([]interface{}); !reflect.DeepEqual(pwwn, []interface{}{"20:21:22:23:45:67:89:ab"}) {
+					t.Errorf("expected the pwwn 20:21:22:23:45:67:89:ab to be added, got %v", pwwn)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+
+			var updated *schema.ResourceData
+			r := testAdoptResource(tc.resource, tc.objectType, tc.existing, &updated)
+
+			diags := testAdoptCreate(t, r, tc.config, testRawConfig(r, tc.rawConfig))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.HasPrefix(diags[0].Summary, "Adopted the existing") {
+				t.Errorf("expected a single warning that the object was adopted, got %v", diags)
+			}
+
+			if updated == nil {
+				t.Fatalf("expected the existing object to be updated")
+			}
+
+			changes := []string{}
+			for key := range r.Schema {
+				if updated.HasChange(key) {
+					changes = append(changes, key)
+				}
+			}
+			sort.Strings(changes)
+			if !reflect.DeepEqual(changes, tc.expectedChanges) {
+				t.Errorf("expected the changes %v, got %v", tc.expectedChanges, changes)
+			}
+
+			tc.check(t, updated)
+		})
+	}
+}
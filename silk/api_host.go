@@ -0,0 +1,44 @@
+package silk
+
+import (
+	"github.com/mitchellh/mapstructure"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// getHostFCPorts returns every PWWN that has been added to a Host on the Silk server. The SDK's GetHostPWWN() makes the
+// same API call but filters the response down to a single Host.
+func getHostFCPorts(silk *silksdp.Credentials, timeout int) (*silksdp.GetHostPWWNResponse, error) {
+
+	apiRequest, err := silk.Get("/host_fc_ports", timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the API Response (map[string]interface{}) to a struct
+	var apiResponse silksdp.GetHostPWWNResponse
+	mapErr := mapstructure.Decode(apiRequest, &apiResponse)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+
+	return &apiResponse, nil
+}
+
+// getHostIQNs returns every IQN that has been added to a Host on the Silk server. The SDK's GetHostIQN() makes the
+// same API call but filters the response down to a single Host.
+func getHostIQNs(silk *silksdp.Credentials, timeout int) (*silksdp.GetHostIQNResponse, error) {
+
+	apiRequest, err := silk.Get("/host_iqns", timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the API Response (map[string]interface{}) to a struct
+	var apiResponse silksdp.GetHostIQNResponse
+	mapErr := mapstructure.Decode(apiRequest, &apiResponse)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+
+	return &apiResponse, nil
+}
@@ -0,0 +1,310 @@
+package silk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// The SDK's mapping functions look the Volume up by name through GetVolumeID, which only searches /volumes. The
+// helpers below work directly with refs so they can also be used with objects such as views (/snapshots/<id>).
+
+// createMapping maps the provided Host or Host Group ref (ex. /hosts/1 or /host_groups/1) to the provided Volume or
+// view ref. A lun of unassignedLun lets the SDP assign the next available LUN.
+func createMapping(silk *silksdp.Credentials, hostRef, volumeRef string, lun, timeout int) (*silksdp.CreateHostVolumeMappingResponse, error) {
+
+	config := map[string]interface{}{}
+	config["host"] = map[string]interface{}{"ref": hostRef}
+	config["volume"] = map[string]interface{}{"ref": volumeRef}
+	if lun != unassignedLun {
+		config["lun"] = lun
+	}
+
+	apiRequest, err := silk.Post("/mappings", config, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the API Response (map[string]interface{}) to a struct
+	var apiResponse silksdp.CreateHostVolumeMappingResponse
+	mapErr := mapstructure.Decode(apiRequest, &apiResponse)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+
+	return &apiResponse, nil
+}
+
+// getMappings returns every Host and Host Group mapping of the provided Volume or view ref.
+func getMappings(silk *silksdp.Credentials, volumeRef string, timeout int) ([]silksdp.IndividualHostMappingResponse, error) {
+
+	mappingsOnServer, err := silk.GetHostMappings(timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings := []silksdp.IndividualHostMappingResponse{}
+	for _, mapping := range mappingsOnServer {
+		if mapping.Volume.Ref == volumeRef {
+			mappings = append(mappings, mapping)
+		}
+	}
+
+	return mappings, nil
+}
+
+// deleteMapping removes the mapping with the provided ID from the Silk server. An error matched by notFound is returned
+// when the mapping does not exist.
+func deleteMapping(silk *silksdp.Credentials, id int, timeout int) error {
+
+	_, err := silk.Delete(fmt.Sprintf("/mappings/%d", id), timeout)
+	if err == nil {
+		return nil
+	}
+
+	// The SDK only reports the status of a failed request, so the mapping is looked up to tell whether it is gone
+	mapping, getErr := getMapping(silk, strconv.Itoa(id), timeout)
+	if getErr == nil && mapping == nil {
+		return notFoundError("The server does not contain a mapping with the ID '%d'", id)
+	}
+
+	return err
+}
+
+// hostRef returns the ref of the Host with the provided name.
+func hostRef(silk *silksdp.Credentials, name string, timeout int) (string, error) {
+
+	hostID, err := silk.GetHostID(name, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("/hosts/%d", hostID), nil
+}
+
+// hostGroupRef returns the ref of the Host Group with the provided name.
+func hostGroupRef(silk *silksdp.Credentials, name string, timeout int) (string, error) {
+
+	hostGroupID, err := silk.GetHostGroupID(name, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("/host_groups/%d", hostGroupID), nil
+}
+
+// mappingNames splits the provided mappings into the names of the mapped Hosts and the names of the mapped
+// Host Groups.
+func mappingNames(silk *silksdp.Credentials, mappings []silksdp.IndividualHostMappingResponse, timeout int) ([]string, []string, error) {
+
+	hosts := []string{}
+	hostGroups := []string{}
+	for _, mapping := range mappings {
+		if strings.HasPrefix(mapping.Host.Ref, "/hosts/") {
+			hostID, err := refID(mapping.Host.Ref, "hosts")
+			if err != nil {
+				return nil, nil, err
+			}
+
+			name, err := silk.GetHostName(hostID, timeout)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			hosts = append(hosts, name)
+		} else if strings.HasPrefix(mapping.Host.Ref, "/host_groups/") {
+			hostGroupID, err := refID(mapping.Host.Ref, "host_groups")
+			if err != nil {
+				return nil, nil, err
+			}
+
+			name, err := silk.GetHostGroupName(hostGroupID, timeout)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			hostGroups = append(hostGroups, name)
+		}
+	}
+
+	return hosts, hostGroups, nil
+}
+
+// flattenMappings converts the provided mappings into the host_mapping and host_group_mapping blocks of a Volume,
+// each holding the name of the mapped object and the LUN the SDP assigned.
+func flattenMappings(silk *silksdp.Credentials, mappings []silksdp.IndividualHostMappingResponse, timeout int) ([]interface{}, []interface{}, error) {
+
+	hostMappings := []interface{}{}
+	hostGroupMappings := []interface{}{}
+	for _, mapping := range mappings {
+		hosts, hostGroups, err := mappingNames(silk, []silksdp.IndividualHostMappingResponse{mapping}, timeout)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, name := range hosts {
+			hostMappings = append(hostMappings, map[string]interface{}{"name": name, "lun": mapping.Lun})
+		}
+
+		for _, name := range hostGroups {
+			hostGroupMappings = append(hostGroupMappings, map[string]interface{}{"name": name, "lun": mapping.Lun})
+		}
+	}
+
+	// Sort the new slices to prevent any TF comparison issues
+	for _, blocks := range [][]interface{}{hostMappings, hostGroupMappings} {
+		sort.Slice(blocks, func(i, j int) bool {
+			return blocks[i].(map[string]interface{})["name"].(string) < blocks[j].(map[string]interface{})["name"].(string)
+		})
+	}
+
+	return hostMappings, hostGroupMappings, nil
+}
+
+// mappedVolumeNames returns the names of the Volumes mapped to each Host and Host Group, keyed by the Host or Host
+// Group ref.
+func mappedVolumeNames(silk *silksdp.Credentials, timeout int) (map[string][]string, error) {
+
+	getVolumes, err := silk.GetVolumes(timeout)
+	if err != nil {
+		return nil, err
+	}
+	volumeNames := map[string]string{}
+	for _, volume := range getVolumes.Hits {
+		volumeNames[fmt.Sprintf("/volumes/%d", volume.ID)] = volume.Name
+	}
+
+	getMappings, err := silk.GetHostMappings(timeout)
+	if err != nil {
+		return nil, err
+	}
+	mappedVolumes := map[string][]string{}
+	for _, mapping := range getMappings {
+		if name, ok := volumeNames[mapping.Volume.Ref]; ok {
+			mappedVolumes[mapping.Host.Ref] = append(mappedVolumes[mapping.Host.Ref], name)
+		}
+	}
+
+	return mappedVolumes, nil
+}
+
+// findMapping returns the mapping between the provided Host or Host Group ref and the provided Volume ref. nil is
+// returned when the two objects are not mapped.
+func findMapping(silk *silksdp.Credentials, hostRef, volumeRef string, timeout int) (*silksdp.IndividualHostMappingResponse, error) {
+
+	mappings, err := getMappings(silk, volumeRef, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mapping := range mappings {
+		if mapping.Host.Ref == hostRef {
+			return &mapping, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getMapping returns the mapping with the provided SDP ID. nil is returned when the mapping does not exist.
+func getMapping(silk *silksdp.Credentials, id string, timeout int) (*silksdp.IndividualHostMappingResponse, error) {
+
+	mappingsOnServer, err := silk.GetHostMappings(timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mapping := range mappingsOnServer {
+		if strconv.Itoa(mapping.ID) == id {
+			return &mapping, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// importMapping converts the ID provided to `terraform import` for a mapping resource into the name of the Volume, the
+// name of the Host or Host Group, and the SDP ID of the mapping. The ID is either the SDP ID of the mapping or
+// <volume name>:<host or host group name>. The collection is either "hosts" or "host_groups".
+func importMapping(silk *silksdp.Credentials, importID, collection string, timeout int) (string, string, int, error) {
+
+	if names := strings.SplitN(importID, ":", 2); len(names) == 2 {
+		var ref string
+		var err error
+		if collection == "hosts" {
+			ref, err = hostRef(silk, names[1], timeout)
+		} else {
+			ref, err = hostGroupRef(silk, names[1], timeout)
+		}
+		if err != nil {
+			return "", "", 0, err
+		}
+
+		volumeID, err := silk.GetVolumeID(names[0], timeout)
+		if err != nil {
+			return "", "", 0, err
+		}
+
+		mapping, err := findMapping(silk, ref, fmt.Sprintf("/volumes/%d", volumeID), timeout)
+		if err != nil {
+			return "", "", 0, err
+		}
+		if mapping == nil {
+			return "", "", 0, notFoundError("The server does not contain a mapping between the Volume '%s' and '%s'", names[0], names[1])
+		}
+
+		return names[0], names[1], mapping.ID, nil
+	}
+
+	mappingsOnServer, err := silk.GetHostMappings(timeout)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	for _, mapping := range mappingsOnServer {
+		if strconv.Itoa(mapping.ID) != importID || !strings.HasPrefix(mapping.Host.Ref, fmt.Sprintf("/%s/", collection)) {
+			continue
+		}
+
+		if !strings.HasPrefix(mapping.Volume.Ref, "/volumes/") {
+			return "", "", 0, fmt.Errorf("The mapping with the ID '%s' is not a Volume mapping", importID)
+		}
+
+		volumeName, name, err := volumeMappingNames(silk, mapping, timeout)
+		if err != nil {
+			return "", "", 0, err
+		}
+
+		return volumeName, name, mapping.ID, nil
+	}
+
+	return "", "", 0, notFoundError("The server does not contain a mapping with the ID '%s'", importID)
+}
+
+// volumeMappingNames returns the name of the Volume and the name of the Host or Host Group the provided mapping joins.
+func volumeMappingNames(silk *silksdp.Credentials, mapping silksdp.IndividualHostMappingResponse, timeout int) (string, string, error) {
+
+	volumeID, err := refID(mapping.Volume.Ref, "volumes")
+	if err != nil {
+		return "", "", err
+	}
+
+	getVolume, err := silk.GetVolumeName(volumeID, timeout)
+	if err != nil {
+		return "", "", err
+	}
+	if len(getVolume.Hits) == 0 {
+		return "", "", notFoundError("The server does not contain a Volume with the ID '%d'", volumeID)
+	}
+
+	hosts, hostGroups, err := mappingNames(silk, []silksdp.IndividualHostMappingResponse{mapping}, timeout)
+	if err != nil {
+		return "", "", err
+	}
+
+	return getVolume.Hits[0].Name, strings.Join(append(hosts, hostGroups...), ""), nil
+}
@@ -0,0 +1,126 @@
+package silk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// objectRef holds the {"ref": "/<collection>/<id>"} objects the SDP API uses to link one object to another
+type objectRef struct {
+	Ref string `mapstructure:"ref"`
+}
+
+// snapshot holds a single Volume Group Snapshot returned by the /snapshots API endpoint.
+//
+// The SDK's GetVolumeGroupSnapshotResponse decodes the volume_group and retention_policy refs as plain strings, which
+// fails against the API, so the provider decodes the /snapshots responses itself.
+type snapshot struct {
+	CreationTime                int         `mapstructure:"creation_time"`
+	Description                 interface{} `mapstructure:"description"`
+	ID                          int         `mapstructure:"id"`
+	IsAutoDeleteable            bool        `mapstructure:"is_auto_deleteable"`
+	IsDeleted                   bool        `mapstructure:"is_deleted"`
+	IsExposable                 bool        `mapstructure:"is_exposable"`
+	Name                        string      `mapstructure:"name"`
+	RetentionPolicy             objectRef   `mapstructure:"retention_policy"`
+	ShortName                   string      `mapstructure:"short_name"`
+	Source                      objectRef   `mapstructure:"source"`
+	VolsnapsProvisionedCapacity int         `mapstructure:"volsnaps_provisioned_capacity"`
+	VolumeGroup                 objectRef   `mapstructure:"volume_group"`
+	Wwn                         interface{} `mapstructure:"wwn"`
+}
+
+// isView returns true when the snapshot is a view, i.e. it was created from another snapshot instead of from a
+// Volume Group.
+func (s snapshot) isView() bool {
+	return strings.HasPrefix(s.Source.Ref, "/snapshots/")
+}
+
+// getSnapshotsResponse holds the response of the GET /snapshots API call
+type getSnapshotsResponse struct {
+	Hits   []snapshot `mapstructure:"hits"`
+	Limit  int        `mapstructure:"limit"`
+	Offset int        `mapstructure:"offset"`
+	Total  int        `mapstructure:"total"`
+}
+
+// getSnapshots returns information on all Volume Group Snapshots found on the Silk server.
+func getSnapshots(silk *silksdp.Credentials, timeout int) (*getSnapshotsResponse, error) {
+
+	apiRequest, err := silk.Get("/snapshots", timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the API Response (map[string]interface{}) to a struct
+	var apiResponse getSnapshotsResponse
+	mapErr := mapstructure.Decode(apiRequest, &apiResponse)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+
+	return &apiResponse, nil
+}
+
+// createSnapshot takes a snapshot of the provided Volume Group under the provided Retention Policy.
+//
+// `deletable` allows the Retention Policy to automatically delete the snapshot once it expires and `exposable`
+// allows views to be created from the snapshot.
+func createSnapshot(silk *silksdp.Credentials, name string, volumeGroupID, retentionPolicyID int, deletable, exposable bool, timeout int) (*snapshot, error) {
+
+	config := map[string]interface{}{}
+	config["short_name"] = name
+	config["source"] = map[string]interface{}{"ref": fmt.Sprintf("/volume_groups/%d", volumeGroupID)}
+	config["retention_policy"] = map[string]interface{}{"ref": fmt.Sprintf("/retention_policies/%d", retentionPolicyID)}
+	config["is_auto_deleteable"] = deletable
+	config["is_exposable"] = exposable
+
+	apiRequest, err := silk.Post("/snapshots", config, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the API Response (map[string]interface{}) to a struct
+	var apiResponse snapshot
+	mapErr := mapstructure.Decode(apiRequest, &apiResponse)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+
+	return &apiResponse, nil
+}
+
+// createView creates a writable view, named after the provided name, from the snapshot with the provided ID.
+func createView(silk *silksdp.Credentials, name string, snapshotID, retentionPolicyID int, timeout int) (*snapshot, error) {
+
+	config := map[string]interface{}{}
+	config["short_name"] = name
+	config["source"] = map[string]interface{}{"ref": fmt.Sprintf("/snapshots/%d", snapshotID)}
+	config["retention_policy"] = map[string]interface{}{"ref": fmt.Sprintf("/retention_policies/%d", retentionPolicyID)}
+	config["is_exposable"] = true
+
+	apiRequest, err := silk.Post("/snapshots", config, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the API Response (map[string]interface{}) to a struct
+	var apiResponse snapshot
+	mapErr := mapstructure.Decode(apiRequest, &apiResponse)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+
+	return &apiResponse, nil
+}
+
+// deleteSnapshot deletes the snapshot, or view, with the provided ID from the Silk server.
+func deleteSnapshot(silk *silksdp.Credentials, id int, timeout int) error {
+
+	_, err := silk.Delete(fmt.Sprintf("/snapshots/%d", id), timeout)
+
+	return err
+}
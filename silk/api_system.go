@@ -0,0 +1,170 @@
+package silk
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// systemState holds the single object returned by the GET /system/state API call
+type systemState struct {
+	State         string `mapstructure:"state"`
+	SystemID      string `mapstructure:"system_id"`
+	SystemName    string `mapstructure:"system_name"`
+	SystemVersion string `mapstructure:"system_version"`
+}
+
+// systemCapacity holds the single object returned by the GET /system/capacity API call. Every capacity is in KB.
+type systemCapacity struct {
+	Allocated   float64 `mapstructure:"allocated"`
+	Free        float64 `mapstructure:"free"`
+	Logical     float64 `mapstructure:"logical"`
+	Provisioned float64 `mapstructure:"provisioned"`
+	State       string  `mapstructure:"state"`
+	Total       float64 `mapstructure:"total"`
+}
+
+// getSystemState returns the state and software version of the Silk server.
+func getSystemState(silk *silksdp.Credentials, timeout int) (*systemState, error) {
+
+	var apiResponse struct {
+		Hits []systemState `mapstructure:"hits"`
+	}
+	if err := getSystemObject(silk, "/system/state", &apiResponse, timeout); err != nil {
+		return nil, err
+	}
+
+	if len(apiResponse.Hits) == 0 {
+		return nil, fmt.Errorf("The server did not return the system state")
+	}
+
+	return &apiResponse.Hits[0], nil
+}
+
+// getSystemCapacity returns the physical and logical capacity of the Silk server.
+func getSystemCapacity(silk *silksdp.Credentials, timeout int) (*systemCapacity, error) {
+
+	var apiResponse struct {
+		Hits []systemCapacity `mapstructure:"hits"`
+	}
+	if err := getSystemObject(silk, "/system/capacity", &apiResponse, timeout); err != nil {
+		return nil, err
+	}
+
+	if len(apiResponse.Hits) == 0 {
+		return nil, fmt.Errorf("The server did not return the system capacity")
+	}
+
+	return &apiResponse.Hits[0], nil
+}
+
+// getNodeCount returns the number of c-nodes (/cnodes) or m-nodes (/mnodes) in the Silk server.
+func getNodeCount(silk *silksdp.Credentials, apiEndpoint string, timeout int) (int, error) {
+
+	var apiResponse struct {
+		Total int `mapstructure:"total"`
+	}
+	if err := getSystemObject(silk, apiEndpoint, &apiResponse, timeout); err != nil {
+		return 0, err
+	}
+
+	return apiResponse.Total, nil
+}
+
+// getSystemObject sends a GET request to the provided API endpoint and decodes the API response into the provided
+// struct pointer.
+func getSystemObject(silk *silksdp.Credentials, apiEndpoint string, apiResponse interface{}, timeout int) error {
+
+	apiRequest, err := silk.Get(apiEndpoint, timeout)
+	if err != nil {
+		return err
+	}
+
+	// Convert the API Response (map[string]interface{}) to a struct
+	return mapstructure.Decode(apiRequest, apiResponse)
+}
+
+// node holds a single c-node returned by the GET /cnodes API call
+type node struct {
+	ID   int    `mapstructure:"id"`
+	Name string `mapstructure:"name"`
+}
+
+// fcTargetPort holds a single FC target port returned by the GET /system/fc_ports API call
+type fcTargetPort struct {
+	ID    int       `mapstructure:"id"`
+	Name  string    `mapstructure:"name"`
+	Wwn   string    `mapstructure:"wwn"`
+	Node  objectRef `mapstructure:"node"`
+	State string    `mapstructure:"state"`
+}
+
+// ethTargetPort holds a single Ethernet data port returned by the GET /system/eth_ports API call
+type ethTargetPort struct {
+	ID    int       `mapstructure:"id"`
+	Name  string    `mapstructure:"name"`
+	Node  objectRef `mapstructure:"node"`
+	State string    `mapstructure:"state"`
+}
+
+// netIP holds a single IP address returned by the GET /system/net_ips API call. The service shows what the IP is
+// used for, for example 'iscsi', 'management', or 'replication'.
+type netIP struct {
+	ID        int       `mapstructure:"id"`
+	IPAddress string    `mapstructure:"ip_address"`
+	Service   string    `mapstructure:"service"`
+	Port      objectRef `mapstructure:"port"`
+}
+
+// getCNodes returns every c-node in the Silk server.
+func getCNodes(silk *silksdp.Credentials, timeout int) ([]node, error) {
+
+	var apiResponse struct {
+		Hits []node `mapstructure:"hits"`
+	}
+	if err := getSystemObject(silk, "/cnodes", &apiResponse, timeout); err != nil {
+		return nil, err
+	}
+
+	return apiResponse.Hits, nil
+}
+
+// getFCTargetPorts returns every FC target port in the Silk server.
+func getFCTargetPorts(silk *silksdp.Credentials, timeout int) ([]fcTargetPort, error) {
+
+	var apiResponse struct {
+		Hits []fcTargetPort `mapstructure:"hits"`
+	}
+	if err := getSystemObject(silk, "/system/fc_ports", &apiResponse, timeout); err != nil {
+		return nil, err
+	}
+
+	return apiResponse.Hits, nil
+}
+
+// getEthTargetPorts returns every Ethernet data port in the Silk server.
+func getEthTargetPorts(silk *silksdp.Credentials, timeout int) ([]ethTargetPort, error) {
+
+	var apiResponse struct {
+		Hits []ethTargetPort `mapstructure:"hits"`
+	}
+	if err := getSystemObject(silk, "/system/eth_ports", &apiResponse, timeout); err != nil {
+		return nil, err
+	}
+
+	return apiResponse.Hits, nil
+}
+
+// getNetIPs returns every IP address configured on the Silk server.
+func getNetIPs(silk *silksdp.Credentials, timeout int) ([]netIP, error) {
+
+	var apiResponse struct {
+		Hits []netIP `mapstructure:"hits"`
+	}
+	if err := getSystemObject(silk, "/system/net_ips", &apiResponse, timeout); err != nil {
+		return nil, err
+	}
+
+	return apiResponse.Hits, nil
+}
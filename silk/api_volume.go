@@ -0,0 +1,74 @@
+package silk
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// The SDK's CreateVolume and CreateVolumeGroup functions only accept whole GB. The helpers below accept the size and
+// quota in KiB, which is the unit the SDP stores them in, so the size and quota attributes can use any unit.
+
+// createVolume creates a new Volume, sized in KiB, on the Silk server.
+func createVolume(silk *silksdp.Credentials, name string, sizeInKiB int, volumeGroupName string, vmware bool, description string, readOnly bool, timeout int) (*silksdp.CreateOrUpdateVolumeResponse, error) {
+
+	volumeGroupID, err := silk.GetVolumeGroupID(volumeGroupName, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	config := map[string]interface{}{}
+	config["name"] = name
+	config["size"] = sizeInKiB
+	config["volume_group"] = map[string]interface{}{"ref": fmt.Sprintf("/volume_groups/%d", volumeGroupID)}
+	config["vmware_support"] = vmware
+	config["description"] = description
+	config["read_only"] = readOnly
+
+	apiRequest, err := silk.Post("/volumes", config, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the API Response (map[string]interface{}) to a struct
+	var apiResponse silksdp.CreateOrUpdateVolumeResponse
+	mapErr := mapstructure.Decode(apiRequest, &apiResponse)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+
+	return &apiResponse, nil
+}
+
+// createVolumeGroup creates a new Volume Group, with a quota in KiB, on the Silk server. A quota of 0 corresponds to an
+// unlimited quota.
+func createVolumeGroup(silk *silksdp.Credentials, name string, quotaInKiB int, enableDeDuplication bool, description string, capacityPolicy string, timeout int) (*silksdp.CreateOrUpdateVolumeGroupResponse, error) {
+
+	// The API expects a ref to the capacity policy, so convert the policy name to its ID
+	capacityPolicyID, err := silk.GetCapacityPolicyID(capacityPolicy, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	config := map[string]interface{}{}
+	config["name"] = name
+	config["quota"] = quotaInKiB
+	config["is_dedup"] = enableDeDuplication
+	config["description"] = description
+	config["capacity_policy"] = map[string]interface{}{"ref": fmt.Sprintf("/vg_capacity_policies/%d", capacityPolicyID)}
+
+	apiRequest, err := silk.Post("/volume_groups", config, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the API Response (map[string]interface{}) to a struct
+	var apiResponse silksdp.CreateOrUpdateVolumeGroupResponse
+	mapErr := mapstructure.Decode(apiRequest, &apiResponse)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+
+	return &apiResponse, nil
+}
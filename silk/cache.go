@@ -0,0 +1,87 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// responseCache holds the responses of the Silk server to GET requests for the duration of a Terraform run. The SDK
+// looks an object up by listing every object of its type (ex. GetVolumes to read a single volume), so without the cache
+// a plan makes one full inventory request per resource and per lookup.
+//
+// Concurrent identical requests are coalesced into a single request to the Silk server. Every other request (POST,
+// PATCH, DELETE) may change the inventory, so the cache is cleared both when it is sent and when it completes. The
+// generation of the cache is part of the key of each request so a response that was requested before a change is
+// never stored, or shared with a request made after the change.
+type responseCache struct {
+	mu         sync.Mutex
+	generation uint64
+	responses  map[string]*gatewayResponse
+
+	group singleflight.Group
+}
+
+// newResponseCache returns an empty responseCache
+func newResponseCache() *responseCache {
+	return &responseCache{responses: map[string]*gatewayResponse{}}
+}
+
+// get returns the cached response to the request, or calls fetch and caches its response when it is successful (200).
+func (c *responseCache) get(ctx context.Context, uri string, header http.Header, fetch func(ctx context.Context) (*gatewayResponse, error)) (*gatewayResponse, error) {
+
+	// The credentials are part of the key so a response is only returned to the user that was allowed to read it
+	key := header.Get("Authorization") + " " + uri
+
+	c.mu.Lock()
+	generation := c.generation
+	if response, ok := c.responses[key]; ok {
+		c.mu.Unlock()
+		return response, nil
+	}
+	c.mu.Unlock()
+
+	// The request is shared by every caller, so it is sent with a context of its own that no caller can cancel. Each
+	// caller stops waiting for it when its own context is done.
+	results := c.group.DoChan(fmt.Sprintf("%d %s", generation, key), func() (interface{}, error) {
+		fetchCtx, cancel := context.WithTimeout(context.Background(), defaultOperationTimeout)
+		defer cancel()
+
+		response, err := fetch(logValuesContext{Context: fetchCtx, values: ctx})
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode == http.StatusOK {
+			c.mu.Lock()
+			if c.generation == generation {
+				c.responses[key] = response
+			}
+			c.mu.Unlock()
+		}
+
+		return response, nil
+	})
+
+	select {
+	case result := <-results:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(*gatewayResponse), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// invalidate clears the cache
+func (c *responseCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.responses = map[string]*gatewayResponse{}
+}
@@ -1,18 +1,260 @@
 package silk
 
-import "github.com/silk-us/silk-sdp-go-sdk/silksdp"
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// The mapping_mode values. In authoritative mode the mappings on the server that are unknown to Terraform are shown as
+// drift and removed. In additive mode they are ignored. When mapping_mode is not set, see listMappingMode.
+const (
+	mappingModeAuthoritative = "authoritative"
+	mappingModeAdditive      = "additive"
+)
+
+// defaultOperationTimeout is the time each operation of a resource is given when it is not set in its timeouts block
+const defaultOperationTimeout = 10 * time.Minute
+
+// defaultRequestTimeout is the number of seconds a request is given when the context of the operation does not have
+// a deadline (ex. during an import)
+const defaultRequestTimeout = 15
 
 // Config is per-provider, specifies where to connect to Rubrik CDM
 type Config struct {
-	Server   string
-	Username string
-	Password string
+	Server                string
+	Username              string
+	Password              string
+	RequestTimeout        int
+	MaxRetries            int
+	RetryBackoff          int
+	MaxConcurrentRequests int
+	CacheEnabled          bool
+
+	CABundle          string
+	ClientCertificate string
+	ClientKey         string
+	TLSServerName     string
+	Insecure          bool
 }
 
-// Client returns a *rubrik.Client to interact with the configured Rubrik CDM instance
-func (c *Config) Client() (*silksdp.Credentials, error) {
+// Client is the meta of the provider that is passed to every resource and data source. It embeds the Silk SDK
+// credentials, which point to the gateway of the provider, and records what was learned about the Silk server when the
+// provider was configured.
+type Client struct {
+	*silksdp.Credentials
+
+	// SDPServer is the address of the Silk server. The Server of the credentials is the address of the gateway.
+	SDPServer string
+
+	// SDPVersion is the software version of the Silk server (ex. 6.0.0), which can be used to gate features that are
+	// not available on every version.
+	SDPVersion string
+
+	gateway *gateway
+	locks   *mutexKV
+}
+
+// lock serializes the operations on the objects of the provided lock keys (see mutexKV) and returns a function that
+// releases them.
+func (c *Client) lock(keys ...string) func() {
+	return c.locks.lock(keys...)
+}
+
+// Client returns a *Client to interact with the configured Silk server. The client sends its requests through a
+// gateway that applies the timeout, retry, and TLS settings of the provider. The credentials and the connection to
+// the Silk server are validated before the client is returned.
+func (c *Config) Client(ctx context.Context) (*Client, diag.Diagnostics) {
+
+	gateway, err := newGateway(c)
+	if err != nil {
+		return nil, diag.Diagnostics{{Severity: diag.Error, Summary: "Invalid TLS configuration", Detail: err.Error()}}
+	}
+
+	// The requests that are not made for a Terraform operation (ex. the probe below) are logged with the logger of
+	// the provider
+	gateway.logCtx = ctx
+
+	if err := gateway.start(); err != nil {
+		return nil, diag.FromErr(err)
+	}
 
-	return silksdp.Connect(c.Server, c.Username, c.Password), nil
+	state, diags := gateway.probe(ctx, c)
+	if diags.HasError() {
+		gateway.close()
+		return nil, diags
+	}
+
+	// Stop the gateway along with the provider
+	if stopCtx, ok := schema.StopContext(ctx); ok {
+		go func() {
+			<-stopCtx.Done()
+			gateway.close()
+		}()
+	}
+
+	return &Client{
+		Credentials: silksdp.Connect(gateway.address(), c.Username, c.Password),
+		SDPServer:   c.Server,
+		SDPVersion:  state.SystemVersion,
+		gateway:     gateway,
+		locks:       newMutexKV(),
+	}, nil
+}
+
+// tlsConfig returns the TLS settings used to connect to the Silk server. The certificate of the Silk server is verified
+// against ca_bundle, or the CA certificates of the system, unless insecure is set.
+func (c *Config) tlsConfig() (*tls.Config, error) {
+
+	config := &tls.Config{
+		ServerName:         c.TLSServerName,
+		InsecureSkipVerify: c.Insecure,
+	}
+
+	if c.CABundle != "" {
+		bundle, err := pemOrFile(c.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read the ca_bundle: %s", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, errors.New("The ca_bundle does not contain a PEM encoded certificate")
+		}
+		config.RootCAs = pool
+	}
+
+	if c.ClientCertificate != "" {
+		certificate, err := pemOrFile(c.ClientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read the client_certificate: %s", err)
+		}
+
+		key, err := pemOrFile(c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read the client_key: %s", err)
+		}
+
+		keyPair, err := tls.X509KeyPair(certificate, key)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to load the client_certificate and client_key: %s", err)
+		}
+		config.Certificates = []tls.Certificate{keyPair}
+	}
+
+	return config, nil
+}
+
+// pemOrFile returns the provided value when it is PEM encoded content. Otherwise the value is the path to a file and
+// its content is returned.
+func pemOrFile(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	return ioutil.ReadFile(value)
+}
+
+// requestTimeout returns the number of seconds left before the deadline of the operation, which is set by the
+// timeouts block of the resource. It is passed to the SDK as the timeout of each call so a call, including its
+// retries, does not outlive the operation.
+func requestTimeout(ctx context.Context) int {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return defaultRequestTimeout
+	}
+
+	seconds := int(time.Until(deadline) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+
+	return seconds
+}
+
+// diagsError returns the first error in the provided diagnostics so the Read functions can be reused by the
+// Importers.
+func diagsError(diags diag.Diagnostics) error {
+	for _, diagnostic := range diags {
+		if diagnostic.Severity == diag.Error {
+			return errors.New(diagnostic.Summary)
+		}
+	}
+
+	return nil
+}
+
+// createFailed handles an error returned by a create step that runs after the object itself was created. When
+// rollback_on_failure is set, rollback removes everything created so far and the resource is removed from the state.
+// Otherwise the resource stays in the state, which Terraform marks as tainted so it is replaced on the next apply.
+func createFailed(d *schema.ResourceData, objectType string, rollback func() error, createErr error) diag.Diagnostics {
+
+	if !d.Get("rollback_on_failure").(bool) {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  createErr.Error(),
+				Detail:   fmt.Sprintf("The %s was created but not every step completed. It is kept in the state as tainted and will be replaced on the next apply. Set rollback_on_failure to true to remove it instead.", objectType),
+			},
+		}
+	}
+
+	if err := rollback(); err != nil {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  createErr.Error(),
+				Detail:   fmt.Sprintf("The rollback of the %s failed: %s. It is kept in the state as tainted and will be replaced on the next apply.", objectType, err),
+			},
+		}
+	}
+
+	d.SetId("")
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  createErr.Error(),
+			Detail:   fmt.Sprintf("The %s, and everything created with it, was removed because rollback_on_failure is set to true.", objectType),
+		},
+	}
+}
+
+// notFoundMessage starts the message of the errors returned, by both the SDK and the provider, when an object does not
+// exist on the Silk server
+const notFoundMessage = "The server does not contain"
+
+// errNotFound is matched by the errors the provider returns when an object does not exist on the Silk server
+var errNotFound = errors.New("object not found")
+
+// notFoundErr is an error for an object that does not exist on the Silk server
+type notFoundErr struct {
+	message string
+}
+
+func (e *notFoundErr) Error() string        { return e.message }
+func (e *notFoundErr) Is(target error) bool { return target == errNotFound }
+
+// notFoundError returns an error, formatted like fmt.Errorf, for an object that does not exist on the Silk server
+func notFoundError(format string, a ...interface{}) error {
+	return &notFoundErr{message: fmt.Sprintf(format, a...)}
+}
+
+// notFound returns true when the error reports that an object does not exist on the Silk server. The SDK only reports
+// it in the message of its errors.
+func notFound(err error) bool {
+	return errors.Is(err, errNotFound) || strings.Contains(err.Error(), notFoundMessage)
 }
 
 // find is a helper function that is used to determine if val is in the slice
@@ -26,6 +268,77 @@ func find(slice []string, val string) (int, bool) {
 	return -1, false
 }
 
+// managedNames returns the names found on the server that are managed by Terraform. In the authoritative mapping
+// mode every name is managed. In the additive mode only the names already stored in the state are managed.
+func managedNames(mappingMode string, state []interface{}, server []string) []string {
+	if mappingMode == mappingModeAuthoritative {
+		return server
+	}
+
+	known := []string{}
+	for _, value := range state {
+		known = append(known, value.(string))
+	}
+
+	managed := []string{}
+	for _, value := range server {
+		if _, found := find(known, value); found {
+			managed = append(managed, value)
+		}
+	}
+
+	return managed
+}
+
+// listMappingMode returns the mapping mode a list attribute (ex. host_mapping) is refreshed with. When mapping_mode is
+// not set, the list is authoritative as soon as it holds a value and ignored while it is empty, like earlier versions
+// of the provider.
+func listMappingMode(mappingMode string, list []interface{}) string {
+	if mappingMode != "" {
+		return mappingMode
+	}
+
+	if len(list) != 0 {
+		return mappingModeAuthoritative
+	}
+
+	return mappingModeAdditive
+}
+
+// orderedNames returns the names found on the server in the order they are stored in the state, followed by the names
+// that are not in the state yet, sorted. Only the membership of a list is compared with the server, so keeping the
+// order of the configuration avoids a perpetual diff when it is not sorted.
+func orderedNames(state []interface{}, server []string) []string {
+
+	ordered := []string{}
+	for _, value := range state {
+		if _, found := find(server, value.(string)); found {
+			ordered = append(ordered, value.(string))
+		}
+	}
+
+	remaining := []string{}
+	for _, value := range server {
+		if _, found := find(ordered, value); !found {
+			remaining = append(remaining, value)
+		}
+	}
+	sort.Strings(remaining)
+
+	return unique(append(ordered, remaining...))
+}
+
+// importMatches returns true when the ID provided to `terraform import` is either the SDP ID or the name of the
+// object.
+func importMatches(importID string, objID int, name string) bool {
+	return importID == strconv.Itoa(objID) || importID == name
+}
+
+// refID strips the collection from the provided SDP ref (ex. /volume_groups/12) and returns the object ID.
+func refID(ref, collection string) (int, error) {
+	return strconv.Atoi(strings.TrimPrefix(ref, fmt.Sprintf("/%s/", collection)))
+}
+
 func unique(stringSlice []string) []string {
     keys := make(map[string]bool)
     list := []string{}	
@@ -0,0 +1,106 @@
+package silk
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestManagedNames(t *testing.T) {
+
+	state := []interface{}{"host01", "host03"}
+	server := []string{"host01", "host02"}
+
+	testCases := map[string]struct {
+		mappingMode string
+		expected    []string
+	}{
+		"authoritative": {
+			mappingMode: mappingModeAuthoritative,
+			expected:    []string{"host01", "host02"},
+		},
+		"additive": {
+			mappingMode: mappingModeAdditive,
+			expected:    []string{"host01"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		managed := managedNames(testCase.mappingMode, state, server)
+		if !reflect.DeepEqual(managed, testCase.expected) {
+			t.Fatalf("%s: expected %v, got %v", name, testCase.expected, managed)
+		}
+	}
+}
+
+func TestListMappingMode(t *testing.T) {
+
+	testCases := map[string]struct {
+		mappingMode string
+		list        []interface{}
+		expected    string
+	}{
+		"not set and empty":     {"", []interface{}{}, mappingModeAdditive},
+		"not set and not empty": {"", []interface{}{"host01"}, mappingModeAuthoritative},
+		"additive":              {mappingModeAdditive, []interface{}{"host01"}, mappingModeAdditive},
+		"authoritative":         {mappingModeAuthoritative, []interface{}{}, mappingModeAuthoritative},
+	}
+
+	for name, testCase := range testCases {
+		if mappingMode := listMappingMode(testCase.mappingMode, testCase.list); mappingMode != testCase.expected {
+			t.Fatalf("%s: expected %s, got %s", name, testCase.expected, mappingMode)
+		}
+	}
+}
+
+func TestOrderedNames(t *testing.T) {
+
+	testCases := map[string]struct {
+		state    []interface{}
+		server   []string
+		expected []string
+	}{
+		"state order": {
+			state:    []interface{}{"host02", "host01"},
+			server:   []string{"host01", "host02"},
+			expected: []string{"host02", "host01"},
+		},
+		"removed on the server": {
+			state:    []interface{}{"host03", "host01"},
+			server:   []string{"host01"},
+			expected: []string{"host01"},
+		},
+		"added on the server": {
+			state:    []interface{}{"host02"},
+			server:   []string{"host04", "host02", "host03"},
+			expected: []string{"host02", "host03", "host04"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		ordered := orderedNames(testCase.state, testCase.server)
+		if !reflect.DeepEqual(ordered, testCase.expected) {
+			t.Fatalf("%s: expected %v, got %v", name, testCase.expected, ordered)
+		}
+	}
+}
+
+func TestNotFound(t *testing.T) {
+
+	testCases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"provider":         {notFoundError("The server does not contain a Host with the ID or name '%s'", "host01"), true},
+		"wrapped provider": {fmt.Errorf("import: %w", notFoundError("The server does not contain a Host")), true},
+		"SDK":              {errors.New("The server does not contain a Host named 'host01'"), true},
+		"other":            {errors.New("Unable to reach the Silk server"), false},
+	}
+
+	for name, testCase := range testCases {
+		if found := notFound(testCase.err); found != testCase.expected {
+			t.Fatalf("%s: expected %t, got %t", name, testCase.expected, found)
+		}
+	}
+}
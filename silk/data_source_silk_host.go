@@ -0,0 +1,201 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+func dataSourceSilkHost() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSilkHostRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "obj_id", "initiator"},
+				Description:  "The name of the Host to look up.",
+			},
+			"obj_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "obj_id", "initiator"},
+				Description:  "The SDP ID of the Host to look up.",
+			},
+			"initiator": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"name", "obj_id", "initiator"},
+				Description:  "A PWWN or IQN used to look up the Host that owns it. PWWNs are matched with or without ':' separators and IQNs are matched case-insensitively.",
+			},
+			"host_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of Host.",
+			},
+			"pwwn": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The PWWNs that are mapped to the Host.",
+			},
+			"iqn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IQN that is mapped to the Host.",
+			},
+			"host_group_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the Host Group the Host belongs to. Empty when the Host is not part of a Host Group.",
+			},
+			"mapped_volumes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The Volumes mapped to the Host, either directly or through its Host Group.",
+			},
+		},
+	}
+}
+
+func dataSourceSilkHostRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	name := d.Get("name").(string)
+	objID := d.Get("obj_id").(int)
+	initiator := d.Get("initiator").(string)
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	hosts, err := flattenHosts(silk, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, host := range hosts {
+		if (name != "" && host["name"] == name) || (objID != 0 && host["obj_id"] == objID) || (initiator != "" && hostOwnsInitiator(host, initiator)) {
+
+			for key, value := range host {
+				d.Set(key, value)
+			}
+
+			d.SetId(strconv.Itoa(host["obj_id"].(int)))
+
+			return diags
+		}
+	}
+
+	if name != "" {
+		return diag.Errorf("The server does not contain a Host named '%s'", name)
+	} else if objID != 0 {
+		return diag.Errorf("The server does not contain a Host with the ID '%d'", objID)
+	}
+
+	return diag.Errorf("The server does not contain a Host with the initiator '%s'", initiator)
+}
+
+// normalizePWWN removes the separators from the provided PWWN and lower cases it so PWWNs written in different styles
+// can be compared.
+func normalizePWWN(pwwn string) string {
+	return strings.ToLower(strings.NewReplacer(":", "", "-", "").Replace(pwwn))
+}
+
+// hostOwnsInitiator returns true when the provided PWWN or IQN has been added to the flattened host.
+func hostOwnsInitiator(host map[string]interface{}, initiator string) bool {
+	for _, pwwn := range host["pwwn"].([]string) {
+		if normalizePWWN(pwwn) == normalizePWWN(initiator) {
+			return true
+		}
+	}
+
+	return host["iqn"] != "" && strings.EqualFold(host["iqn"].(string), initiator)
+}
+
+// flattenHosts returns every Host on the Silk server, flattened into the attributes shared by the silk_host and
+// silk_hosts data sources. Every list is only requested once and then used to convert the refs of each Host back
+// to names.
+func flattenHosts(silk *silksdp.Credentials, timeout int) ([]map[string]interface{}, error) {
+
+	getHosts, err := silk.GetHosts(timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	getHostGroups, err := silk.GetHostGroups(timeout)
+	if err != nil {
+		return nil, err
+	}
+	hostGroupNames := map[string]string{}
+	for _, hostGroup := range getHostGroups.Hits {
+		hostGroupNames[fmt.Sprintf("/host_groups/%d", hostGroup.ID)] = hostGroup.Name
+	}
+
+	getPWWNs, err := getHostFCPorts(silk, timeout)
+	if err != nil {
+		return nil, err
+	}
+	pwwns := map[string][]string{}
+	for _, pwwn := range getPWWNs.Hits {
+		pwwns[pwwn.Host.Ref] = append(pwwns[pwwn.Host.Ref], pwwn.Pwwn)
+	}
+
+	getIQNs, err := getHostIQNs(silk, timeout)
+	if err != nil {
+		return nil, err
+	}
+	iqns := map[string]string{}
+	for _, iqn := range getIQNs.Hits {
+		iqns[iqn.Host.Ref] = iqn.Iqn
+	}
+
+	mappedVolumes, err := mappedVolumeNames(silk, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := []map[string]interface{}{}
+	for _, host := range getHosts.Hits {
+		ref := fmt.Sprintf("/hosts/%d", host.ID)
+
+		hostPWWNs := append([]string{}, pwwns[ref]...)
+		volumes := append([]string{}, mappedVolumes[ref]...)
+		hostGroupName := ""
+		if host.IsPartOfGroup {
+			hostGroupName = hostGroupNames[host.HostGroup.Ref]
+			volumes = unique(append(volumes, mappedVolumes[host.HostGroup.Ref]...))
+		}
+
+		// Sort the new slices to prevent any TF comparison issues
+		sort.Strings(hostPWWNs)
+		sort.Strings(volumes)
+
+		hosts = append(hosts, map[string]interface{}{
+			"name":            host.Name,
+			"obj_id":          host.ID,
+			"host_type":       host.Type,
+			"pwwn":            hostPWWNs,
+			"iqn":             iqns[ref],
+			"host_group_name": hostGroupName,
+			"mapped_volumes":  volumes,
+		})
+	}
+
+	return hosts, nil
+}
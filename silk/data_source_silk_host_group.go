@@ -0,0 +1,115 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSilkHostGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSilkHostGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "obj_id"},
+				Description:  "The name of the Host Group to look up.",
+			},
+			"obj_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "obj_id"},
+				Description:  "The SDP ID of the Host Group to look up.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The description of the Host Group.",
+			},
+			"allow_different_host_types": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the 'Enable mixed host OS types' checkbox is enabled on the Host Group.",
+			},
+			"host_mapping": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The Hosts that belong to the Host Group.",
+			},
+			"mapped_volumes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The Volumes mapped to the Host Group.",
+			},
+		},
+	}
+}
+
+func dataSourceSilkHostGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	name := d.Get("name").(string)
+	objID := d.Get("obj_id").(int)
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	getHostGroups, err := silk.GetHostGroups(timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, hostGroup := range getHostGroups.Hits {
+		if (name != "" && hostGroup.Name == name) || (objID != 0 && hostGroup.ID == objID) {
+
+			// Get the hosts in the host group
+			hostsInHostGroup, err := silk.GetHostGroupHosts(hostGroup.Name, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			mappedVolumes, err := mappedVolumeNames(silk, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			volumes := append([]string{}, mappedVolumes[fmt.Sprintf("/host_groups/%d", hostGroup.ID)]...)
+
+			// Sort the new slices to prevent any TF comparison issues
+			sort.Strings(hostsInHostGroup)
+			sort.Strings(volumes)
+
+			d.Set("name", hostGroup.Name)
+			d.Set("obj_id", hostGroup.ID)
+			d.Set("description", hostGroup.Description)
+			d.Set("allow_different_host_types", hostGroup.AllowDifferentHostTypes)
+			d.Set("host_mapping", hostsInHostGroup)
+			d.Set("mapped_volumes", volumes)
+
+			d.SetId(strconv.Itoa(hostGroup.ID))
+
+			return diags
+		}
+	}
+
+	if name != "" {
+		return diag.Errorf("The server does not contain a Host Group named '%s'", name)
+	}
+
+	return diag.Errorf("The server does not contain a Host Group with the ID '%d'", objID)
+}
@@ -0,0 +1,63 @@
+package silk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccSilkDataSourceHostGroup is the main function that is executed during the test process.
+func TestAccSilkDataSourceHostGroup(t *testing.T) {
+
+	// Required Silk Centric Variables.
+	var hostGroupName = "TerraformTestAccDataHostGroup"
+	var hostName = "TerraformTestAccDataHostGroupHost"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSilkDataSourceHostGroupConfig(hostGroupName, hostName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.silk_host_group.by_name", "obj_id", "silk_host_group.testacc", "obj_id"),
+					resource.TestCheckResourceAttrPair("data.silk_host_group.by_id", "name", "silk_host_group.testacc", "name"),
+					resource.TestCheckResourceAttr("data.silk_host_group.by_name", "allow_different_host_types", "false"),
+					resource.TestCheckResourceAttr("data.silk_host_group.by_name", "host_mapping.#", "1"),
+					resource.TestCheckResourceAttr("data.silk_host_group.by_name", "host_mapping.0", hostName),
+					resource.TestCheckResourceAttr("data.silk_host_group.by_name", "mapped_volumes.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSilkDataSourceHostGroupConfig returns a silk_host_group resource with a single Host and two
+// silk_host_group data sources that look it up by name and by ID
+func testAccCheckSilkDataSourceHostGroupConfig(hostGroupName, hostName string) string {
+	return fmt.Sprintf(`
+	resource "silk_host" "testacc" {
+		name = "%s"
+		host_type = "Linux"
+		pwwn = []
+	}
+
+	resource "silk_host_group" "testacc" {
+		name = "%s"
+		description = "Host Group used for Terraform silk_host_group data source Acceptance Testing"
+		host_mapping = [silk_host.testacc.name]
+	}
+
+	data "silk_host_group" "by_name" {
+		name = silk_host_group.testacc.name
+	}
+
+	data "silk_host_group" "by_id" {
+		obj_id = silk_host_group.testacc.obj_id
+	}
+	`, hostName, hostGroupName)
+
+}
@@ -0,0 +1,61 @@
+package silk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccSilkDataSourceHost is the main function that is executed during the test process.
+func TestAccSilkDataSourceHost(t *testing.T) {
+
+	// Required Silk Centric Variables.
+	var hostName = "TerraformTestAccDataHost"
+	var pwwn = "20:21:22:23:45:67:89:ac"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSilkDataSourceHostConfig(hostName, pwwn),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.silk_host.by_name", "obj_id", "silk_host.testacc", "obj_id"),
+					resource.TestCheckResourceAttrPair("data.silk_host.by_id", "name", "silk_host.testacc", "name"),
+					resource.TestCheckResourceAttr("data.silk_host.by_initiator", "name", hostName),
+					resource.TestCheckResourceAttr("data.silk_host.by_name", "host_type", "Linux"),
+					resource.TestCheckResourceAttr("data.silk_host.by_name", "pwwn.#", "1"),
+					resource.TestCheckResourceAttr("data.silk_host.by_name", "host_group_name", ""),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSilkDataSourceHostConfig returns a silk_host resource and three silk_host data sources that look
+// it up by name, by ID, and by initiator
+func testAccCheckSilkDataSourceHostConfig(hostName, pwwn string) string {
+	return fmt.Sprintf(`
+	resource "silk_host" "testacc" {
+		name = "%s"
+		host_type = "Linux"
+		pwwn = ["%s"]
+	}
+
+	data "silk_host" "by_name" {
+		name = silk_host.testacc.name
+	}
+
+	data "silk_host" "by_id" {
+		obj_id = silk_host.testacc.obj_id
+	}
+
+	data "silk_host" "by_initiator" {
+		initiator = upper(replace(silk_host.testacc.pwwn[0], ":", ""))
+	}
+	`, hostName, pwwn)
+
+}
@@ -0,0 +1,140 @@
+package silk
+
+import (
+	"context"
+	"hash/crc32"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceSilkHosts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSilkHostsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only return the Hosts whose name matches this regular expression.",
+			},
+			"host_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the Hosts of this type.",
+			},
+			"host_group_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the Hosts that belong to this Host Group.",
+			},
+			"hosts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Hosts that match every provided filter, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the Host.",
+						},
+						"obj_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The SDP ID of the Host.",
+						},
+						"host_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of Host.",
+						},
+						"pwwn": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The PWWNs that are mapped to the Host.",
+						},
+						"iqn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IQN that is mapped to the Host.",
+						},
+						"host_group_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the Host Group the Host belongs to.",
+						},
+						"mapped_volumes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The Volumes mapped to the Host, either directly or through its Host Group.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSilkHostsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	hostTypeFilter := d.Get("host_type").(string)
+	hostGroupFilter := d.Get("host_group_name").(string)
+	timeout := requestTimeout(ctx)
+
+	var nameRegex *regexp.Regexp
+	if value, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(value.(string))
+	}
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	allHosts, err := flattenHosts(silk, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hosts := []map[string]interface{}{}
+	for _, host := range allHosts {
+		if nameRegex != nil && !nameRegex.MatchString(host["name"].(string)) {
+			continue
+		}
+		if hostTypeFilter != "" && host["host_type"] != hostTypeFilter {
+			continue
+		}
+		if hostGroupFilter != "" && host["host_group_name"] != hostGroupFilter {
+			continue
+		}
+
+		hosts = append(hosts, host)
+	}
+
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i]["name"].(string) < hosts[j]["name"].(string)
+	})
+
+	if err := d.Set("hosts", hosts); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The ID is derived from the filters so the same query always produces the same ID
+	filters := []string{d.Get("name_regex").(string), hostTypeFilter, hostGroupFilter}
+	d.SetId(strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(strings.Join(filters, "|")))), 10))
+
+	return diags
+}
@@ -0,0 +1,66 @@
+package silk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccSilkDataSourceHosts is the main function that is executed during the test process.
+func TestAccSilkDataSourceHosts(t *testing.T) {
+
+	// Required Silk Centric Variables.
+	var hostNamePrefix = "TerraformTestAccDataHosts"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSilkDataSourceHostsConfig(hostNamePrefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.silk_hosts.by_name_regex", "hosts.#", "2"),
+					resource.TestCheckResourceAttr("data.silk_hosts.by_name_regex", "hosts.0.name", hostNamePrefix+"Linux"),
+					resource.TestCheckResourceAttr("data.silk_hosts.by_name_regex", "hosts.1.name", hostNamePrefix+"Windows"),
+					resource.TestCheckResourceAttr("data.silk_hosts.by_host_type", "hosts.#", "1"),
+					resource.TestCheckResourceAttr("data.silk_hosts.by_host_type", "hosts.0.host_type", "Windows"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSilkDataSourceHostsConfig returns two silk_host resources and silk_hosts data sources that filter
+// them by name and by host type
+func testAccCheckSilkDataSourceHostsConfig(hostNamePrefix string) string {
+	return fmt.Sprintf(`
+	resource "silk_host" "linux" {
+		name = "%[1]sLinux"
+		host_type = "Linux"
+		pwwn = []
+	}
+
+	resource "silk_host" "windows" {
+		name = "%[1]sWindows"
+		host_type = "Windows"
+		pwwn = []
+	}
+
+	data "silk_hosts" "by_name_regex" {
+		name_regex = "^%[1]s"
+
+		depends_on = [silk_host.linux, silk_host.windows]
+	}
+
+	data "silk_hosts" "by_host_type" {
+		name_regex = "^%[1]s"
+		host_type = "Windows"
+
+		depends_on = [silk_host.linux, silk_host.windows]
+	}
+	`, hostNamePrefix)
+
+}
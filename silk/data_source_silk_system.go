@@ -0,0 +1,152 @@
+package silk
+
+import (
+	"context"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSilkSystem() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSilkSystemRead,
+
+		Schema: map[string]*schema.Schema{
+			"system_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the Silk system.",
+			},
+			"system_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the Silk system.",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SDP software version running on the Silk system.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The overall state of the Silk system, for example 'online'.",
+			},
+			"capacity_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The capacity state of the Silk system, for example 'healthy'.",
+			},
+			"physical_total_in_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total physical capacity, in GB, of the Silk system.",
+			},
+			"physical_used_in_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The physical capacity, in GB, allocated on the Silk system.",
+			},
+			"physical_free_in_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The free physical capacity, in GB, of the Silk system.",
+			},
+			"logical_total_in_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The logical capacity, in GB, provisioned to Volumes, Snapshots, and views on the Silk system.",
+			},
+			"logical_used_in_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The logical capacity, in GB, written by hosts before data reduction.",
+			},
+			"logical_free_in_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The provisioned logical capacity, in GB, that has not been written yet.",
+			},
+			"data_reduction_ratio": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The ratio between the logical capacity used and the physical capacity used, rounded to two decimal places. 0 when no physical capacity is used.",
+			},
+			"cnode_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of c-nodes in the Silk system.",
+			},
+			"mnode_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of m-nodes in the Silk system.",
+			},
+		},
+	}
+}
+
+func dataSourceSilkSystemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	state, err := getSystemState(silk, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	capacity, err := getSystemCapacity(silk, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cnodeCount, err := getNodeCount(silk, "/cnodes", timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	mnodeCount, err := getNodeCount(silk, "/mnodes", timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	dataReductionRatio := 0.0
+	if capacity.Allocated != 0 {
+		dataReductionRatio = math.Round(capacity.Logical/capacity.Allocated*100) / 100
+	}
+
+	d.Set("system_name", state.SystemName)
+	d.Set("system_id", state.SystemID)
+	d.Set("version", state.SystemVersion)
+	d.Set("state", state.State)
+	d.Set("capacity_state", capacity.State)
+	d.Set("physical_total_in_gb", kbToGB(capacity.Total))
+	d.Set("physical_used_in_gb", kbToGB(capacity.Allocated))
+	d.Set("physical_free_in_gb", kbToGB(capacity.Free))
+	d.Set("logical_total_in_gb", kbToGB(capacity.Provisioned))
+	d.Set("logical_used_in_gb", kbToGB(capacity.Logical))
+	d.Set("logical_free_in_gb", kbToGB(math.Max(capacity.Provisioned-capacity.Logical, 0)))
+	d.Set("data_reduction_ratio", dataReductionRatio)
+	d.Set("cnode_count", cnodeCount)
+	d.Set("mnode_count", mnodeCount)
+
+	// The data source always describes the system the provider is connected to
+	if state.SystemID != "" {
+		d.SetId(state.SystemID)
+	} else {
+		d.SetId(m.(*Client).SDPServer)
+	}
+
+	return diags
+}
+
+// kbToGB converts a capacity returned by the API, in KB, to whole GB.
+func kbToGB(kb float64) int {
+	return int(kb / 1024 / 1024)
+}
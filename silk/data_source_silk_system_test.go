@@ -0,0 +1,38 @@
+package silk
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccSilkDataSourceSystem is the main function that is executed during the test process.
+func TestAccSilkDataSourceSystem(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSilkDataSourceSystemConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.silk_system.testacc", "version"),
+					resource.TestCheckResourceAttrSet("data.silk_system.testacc", "state"),
+					resource.TestCheckResourceAttrSet("data.silk_system.testacc", "physical_total_in_gb"),
+					resource.TestCheckResourceAttrSet("data.silk_system.testacc", "physical_free_in_gb"),
+					resource.TestCheckResourceAttrSet("data.silk_system.testacc", "data_reduction_ratio"),
+					resource.TestCheckResourceAttrSet("data.silk_system.testacc", "cnode_count"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSilkDataSourceSystemConfig returns a silk_system data source
+func testAccCheckSilkDataSourceSystemConfig() string {
+	return `
+	data "silk_system" "testacc" {}
+	`
+}
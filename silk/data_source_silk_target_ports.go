@@ -0,0 +1,153 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceSilkTargetPorts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSilkTargetPortsRead,
+
+		Schema: map[string]*schema.Schema{
+			"protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"iscsi", "fc"}, false),
+				Description:  "Only return the target ports that use this protocol. Valid options are 'iscsi' and 'fc'.",
+			},
+			"ports": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The data ports of the Silk server, sorted by protocol, c-node, and name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the port.",
+						},
+						"protocol": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The protocol of the port, either 'iscsi' or 'fc'.",
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The iSCSI portal IP address or the FC target WWPN of the port.",
+						},
+						"cnode": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the c-node the port belongs to.",
+						},
+						"link_state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The link state of the port.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSilkTargetPortsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	protocolFilter := d.Get("protocol").(string)
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	cnodes, err := getCNodes(silk, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	cnodeNames := map[string]string{}
+	for _, cnode := range cnodes {
+		cnodeNames[fmt.Sprintf("/cnodes/%d", cnode.ID)] = cnode.Name
+	}
+
+	ports := []map[string]interface{}{}
+
+	if protocolFilter == "" || protocolFilter == "iscsi" {
+		ethPorts, err := getEthTargetPorts(silk, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ethPortsByRef := map[string]ethTargetPort{}
+		for _, ethPort := range ethPorts {
+			ethPortsByRef[fmt.Sprintf("/system/eth_ports/%d", ethPort.ID)] = ethPort
+		}
+
+		netIPs, err := getNetIPs(silk, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// Only the IPs used by the iSCSI service are portals, the others are used for management and replication
+		for _, ip := range netIPs {
+			if ip.Service != "iscsi" {
+				continue
+			}
+
+			ethPort := ethPortsByRef[ip.Port.Ref]
+			ports = append(ports, map[string]interface{}{
+				"name":       ethPort.Name,
+				"protocol":   "iscsi",
+				"address":    ip.IPAddress,
+				"cnode":      cnodeNames[ethPort.Node.Ref],
+				"link_state": ethPort.State,
+			})
+		}
+	}
+
+	if protocolFilter == "" || protocolFilter == "fc" {
+		fcPorts, err := getFCTargetPorts(silk, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, fcPort := range fcPorts {
+			ports = append(ports, map[string]interface{}{
+				"name":       fcPort.Name,
+				"protocol":   "fc",
+				"address":    fcPort.Wwn,
+				"cnode":      cnodeNames[fcPort.Node.Ref],
+				"link_state": fcPort.State,
+			})
+		}
+	}
+
+	// Sort the ports to keep the output stable between runs
+	sort.Slice(ports, func(i, j int) bool {
+		for _, key := range []string{"protocol", "cnode", "name", "address"} {
+			if ports[i][key] != ports[j][key] {
+				return ports[i][key].(string) < ports[j][key].(string)
+			}
+		}
+		return false
+	})
+
+	if err := d.Set("ports", ports); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if protocolFilter == "" {
+		d.SetId("all")
+	} else {
+		d.SetId(protocolFilter)
+	}
+
+	return diags
+}
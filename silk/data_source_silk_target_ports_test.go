@@ -0,0 +1,39 @@
+package silk
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccSilkDataSourceTargetPorts is the main function that is executed during the test process.
+func TestAccSilkDataSourceTargetPorts(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSilkDataSourceTargetPortsConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.silk_target_ports.all", "ports.#"),
+					resource.TestCheckResourceAttrSet("data.silk_target_ports.iscsi", "ports.#"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSilkDataSourceTargetPortsConfig returns a silk_target_ports data source for every protocol and one
+// filtered down to the iSCSI portals
+func testAccCheckSilkDataSourceTargetPortsConfig() string {
+	return `
+	data "silk_target_ports" "all" {}
+
+	data "silk_target_ports" "iscsi" {
+		protocol = "iscsi"
+	}
+	`
+}
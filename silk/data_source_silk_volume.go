@@ -0,0 +1,179 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSilkVolume() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSilkVolumeRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "obj_id"},
+				Description:  "The name of the Volume to look up.",
+			},
+			"obj_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "obj_id"},
+				Description:  "The SDP ID of the Volume to look up.",
+			},
+			"size_in_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size, in GB, of the Volume.",
+			},
+			"volume_group_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The SDP ID of the Volume Group the Volume belongs to.",
+			},
+			"volume_group_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the Volume Group the Volume belongs to.",
+			},
+			"vmware": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "This value corresponds to the 'VMware support' checkbox in the UI and specifies whether VMFS is enabled.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The description of the Volume.",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "This value corresponds to the 'Exposure Type' radio button in the UI and specifies whether the volume is 'Read/Write' or 'Read Only'.",
+			},
+			"host_mapping": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the Host.",
+						},
+						"lun": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The LUN the Volume is presented to the Host as.",
+						},
+					},
+				},
+				Description: "The Hosts the Volume is mapped to.",
+			},
+			"host_group_mapping": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the Host Group.",
+						},
+						"lun": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The LUN the Volume is presented to the Hosts in the Host Group as.",
+						},
+					},
+				},
+				Description: "The Host Groups the Volume is mapped to.",
+			},
+			"scsi_sn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The scsi serial number as string.",
+			},
+		},
+	}
+}
+
+func dataSourceSilkVolumeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	name := d.Get("name").(string)
+	objID := d.Get("obj_id").(int)
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	getVolume, err := silk.GetVolumes(timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, volume := range getVolume.Hits {
+		if (name != "" && volume.Name == name) || (objID != 0 && volume.ID == objID) {
+
+			// Since the API shows the Volume Group as an ID, we have to strip the ID from the provided ref and then
+			// look up the volume group name based off of that ID.
+			volumeGroupRefID, err := refID(volume.VolumeGroup.Ref, "volume_groups")
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			getVolumeGroups, err := silk.GetVolumeGroups(timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			for _, volumeGroup := range getVolumeGroups.Hits {
+				if volumeGroup.ID == volumeGroupRefID {
+					d.Set("volume_group_id", volumeGroupRefID)
+					d.Set("volume_group_name", volumeGroup.Name)
+				}
+			}
+
+			// Get the current Hosts and Host Groups mapped to the volume, along with the LUN of each mapping
+			mappings, err := getMappings(silk, fmt.Sprintf("/volumes/%d", volume.ID), timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			hostMappings, hostGroupMappings, err := flattenMappings(silk, mappings, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			d.Set("host_mapping", hostMappings)
+			d.Set("host_group_mapping", hostGroupMappings)
+
+			d.Set("name", volume.Name)
+			d.Set("obj_id", volume.ID)
+			d.Set("size_in_gb", volume.Size/1024/1024) // Convert to GB
+			d.Set("vmware", volume.VmwareSupport)
+			d.Set("description", volume.Description)
+			d.Set("read_only", volume.ReadOnly)
+			d.Set("scsi_sn", volume.ScsiSn)
+
+			d.SetId(strconv.Itoa(volume.ID))
+
+			return diags
+		}
+	}
+
+	if name != "" {
+		return diag.Errorf("The server does not contain a Volume named '%s'", name)
+	}
+
+	return diag.Errorf("The server does not contain a Volume with the ID '%d'", objID)
+}
@@ -0,0 +1,131 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSilkVolumeGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSilkVolumeGroupRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "obj_id"},
+				Description:  "The name of the Volume Group to look up.",
+			},
+			"obj_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "obj_id"},
+				Description:  "The SDP ID of the Volume Group to look up.",
+			},
+			"quota_in_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size quota, in GB, of the Volume Group, rounded down to a whole GB. A value of 0 corresponds to an Unlimited Quota.",
+			},
+			"quota": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The size quota of the Volume Group, in the largest binary unit that represents it exactly (ex. 1536GiB). A value of 0 corresponds to an Unlimited Quota.",
+			},
+			"enable_deduplication": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the Provisioning Type of the Volume Group is 'thin provisioning with dedupe'.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The description of the Volume Group.",
+			},
+			"capacity_policy": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The capacity threshold policy profile of the Volume Group.",
+			},
+			"volumes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The names of the Volumes that belong to the Volume Group.",
+			},
+		},
+	}
+}
+
+func dataSourceSilkVolumeGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	name := d.Get("name").(string)
+	objID := d.Get("obj_id").(int)
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	getVolumeGroups, err := silk.GetVolumeGroups(timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, volumeGroup := range getVolumeGroups.Hits {
+		if (name != "" && volumeGroup.Name == name) || (objID != 0 && volumeGroup.ID == objID) {
+
+			capacityPolicyName := ""
+			if volumeGroup.CapacityPolicy != nil {
+				capacityPolicyName, err = volumeGroupCapacityPolicyName(silk, volumeGroup.CapacityPolicy, timeout)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+			}
+
+			getVolumes, err := silk.GetVolumes(timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			volumes := []string{}
+			for _, volume := range getVolumes.Hits {
+				if volume.VolumeGroup.Ref == fmt.Sprintf("/volume_groups/%d", volumeGroup.ID) {
+					volumes = append(volumes, volume.Name)
+				}
+			}
+
+			// Sort the new slice to prevent any TF comparison issues
+			sort.Strings(volumes)
+
+			d.Set("name", volumeGroup.Name)
+			d.Set("obj_id", volumeGroup.ID)
+			d.Set("quota_in_gb", volumeGroupQuotaInGB(volumeGroup.Quota))
+			d.Set("quota", formatCapacity(volumeGroupQuotaInKiB(volumeGroup.Quota)))
+			d.Set("enable_deduplication", volumeGroup.IsDedup)
+			d.Set("description", volumeGroup.Description)
+			d.Set("capacity_policy", capacityPolicyName)
+			d.Set("volumes", volumes)
+
+			d.SetId(strconv.Itoa(volumeGroup.ID))
+
+			return diags
+		}
+	}
+
+	if name != "" {
+		return diag.Errorf("The server does not contain a Volume Group named '%s'", name)
+	}
+
+	return diag.Errorf("The server does not contain a Volume Group with the ID '%d'", objID)
+}
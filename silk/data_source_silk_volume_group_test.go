@@ -0,0 +1,70 @@
+package silk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccSilkDataSourceVolumeGroup is the main function that is executed during the test process.
+func TestAccSilkDataSourceVolumeGroup(t *testing.T) {
+
+	// Required Silk Centric Variables.
+	var volumeGroupName = "TerraformTestAccDataVolumeGroup"
+	var volumeName = "TerraformTestAccDataVolumeGroupVolume"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSilkDataSourceVolumeGroupConfig(volumeGroupName, volumeName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.silk_volume_group.by_name", "obj_id", "silk_volume_group.testacc", "obj_id"),
+					resource.TestCheckResourceAttrPair("data.silk_volume_group.by_id", "name", "silk_volume_group.testacc", "name"),
+					resource.TestCheckResourceAttr("data.silk_volume_group.by_name", "quota_in_gb", "20"),
+					resource.TestCheckResourceAttr("data.silk_volume_group.by_name", "quota", "20GiB"),
+					resource.TestCheckResourceAttr("data.silk_volume_group.by_name", "enable_deduplication", "true"),
+					resource.TestCheckResourceAttr("data.silk_volume_group.by_name", "capacity_policy", "default_vg_capacity_policy"),
+					resource.TestCheckResourceAttr("data.silk_volume_group.by_name", "volumes.#", "1"),
+					resource.TestCheckResourceAttr("data.silk_volume_group.by_name", "volumes.0", volumeName),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSilkDataSourceVolumeGroupConfig returns a silk_volume_group resource with a single Volume and two
+// silk_volume_group data sources that look it up by name and by ID
+func testAccCheckSilkDataSourceVolumeGroupConfig(volumeGroupName, volumeName string) string {
+	return fmt.Sprintf(`
+	resource "silk_volume_group" "testacc" {
+		name = "%s"
+		quota_in_gb = 20
+		enable_deduplication = true
+		description = "Volume Group used for Terraform silk_volume_group data source Acceptance Testing"
+	}
+
+	resource "silk_volume" "testacc" {
+		name = "%s"
+		size_in_gb = 10
+		volume_group_name = silk_volume_group.testacc.name
+		description = "Volume used for Terraform silk_volume_group data source Acceptance Testing"
+		allow_destroy = true
+	}
+
+	data "silk_volume_group" "by_name" {
+		name = silk_volume_group.testacc.name
+
+		depends_on = [silk_volume.testacc]
+	}
+
+	data "silk_volume_group" "by_id" {
+		obj_id = silk_volume_group.testacc.obj_id
+	}
+	`, volumeGroupName, volumeName)
+
+}
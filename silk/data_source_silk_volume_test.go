@@ -0,0 +1,65 @@
+package silk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccSilkDataSourceVolume is the main function that is executed during the test process.
+func TestAccSilkDataSourceVolume(t *testing.T) {
+
+	// Required Silk Centric Variables.
+	var volumeName = "TerraformTestAccDataVolume"
+	var volumeGroupName = "TerraformTestAccDataVolumeVG"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSilkDataSourceVolumeConfig(volumeName, volumeGroupName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.silk_volume.by_name", "obj_id", "silk_volume.testacc", "obj_id"),
+					resource.TestCheckResourceAttrPair("data.silk_volume.by_id", "name", "silk_volume.testacc", "name"),
+					resource.TestCheckResourceAttr("data.silk_volume.by_name", "size_in_gb", "10"),
+					resource.TestCheckResourceAttr("data.silk_volume.by_name", "volume_group_name", volumeGroupName),
+					resource.TestCheckResourceAttrSet("data.silk_volume.by_name", "scsi_sn"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSilkDataSourceVolumeConfig returns a silk_volume resource and two silk_volume data sources that look
+// it up by name and by ID
+func testAccCheckSilkDataSourceVolumeConfig(volumeName, volumeGroupName string) string {
+	return fmt.Sprintf(`
+	resource "silk_volume_group" "testacc" {
+		name = "%s"
+		quota_in_gb = 20
+		enable_deduplication = true
+		description = "Volume Group used for Terraform silk_volume data source Acceptance Testing"
+	}
+
+	resource "silk_volume" "testacc" {
+		name = "%s"
+		size_in_gb = 10
+		volume_group_name = silk_volume_group.testacc.name
+		description = "Volume used for Terraform silk_volume data source Acceptance Testing"
+		allow_destroy = true
+	}
+
+	data "silk_volume" "by_name" {
+		name = silk_volume.testacc.name
+	}
+
+	data "silk_volume" "by_id" {
+		obj_id = silk_volume.testacc.obj_id
+	}
+	`, volumeGroupName, volumeName)
+
+}
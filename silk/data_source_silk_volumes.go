@@ -0,0 +1,284 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceSilkVolumes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSilkVolumesRead,
+
+		Schema: map[string]*schema.Schema{
+			"volume_group_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the Volumes that belong to this Volume Group.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only return the Volumes whose name matches this regular expression.",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the Volumes that are mapped to this Host.",
+			},
+			"host_group": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the Volumes that are mapped to this Host Group.",
+			},
+			"vmware": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return the Volumes with a matching 'VMware support' setting.",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only return the Volumes with a matching 'Exposure Type' setting.",
+			},
+			"volumes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Volumes that match every provided filter, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the Volume.",
+						},
+						"obj_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The SDP ID of the Volume.",
+						},
+						"size_in_gb": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The size, in GB, of the Volume.",
+						},
+						"volume_group_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The SDP ID of the Volume Group the Volume belongs to.",
+						},
+						"volume_group_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the Volume Group the Volume belongs to.",
+						},
+						"vmware": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the 'VMware support' checkbox is enabled on the Volume.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the Volume.",
+						},
+						"read_only": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the Volume is 'Read Only'.",
+						},
+						"host_mapping": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The Hosts the Volume is mapped to.",
+						},
+						"host_group_mapping": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The Host Groups the Volume is mapped to.",
+						},
+						"scsi_sn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The scsi serial number as string.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSilkVolumesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	volumeGroupFilter := d.Get("volume_group_name").(string)
+	hostFilter := d.Get("host").(string)
+	hostGroupFilter := d.Get("host_group").(string)
+	timeout := requestTimeout(ctx)
+
+	// The raw configuration is used so a filter explicitly set to false is not ignored
+	vmwareFilter, vmwareSet := configuredBool(d, "vmware")
+	readOnlyFilter, readOnlySet := configuredBool(d, "read_only")
+
+	var nameRegex *regexp.Regexp
+	if value, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(value.(string))
+	}
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	// Every list is only requested once and then used to convert the refs of each Volume back to names, instead
+	// of looking the names up for each Volume individually.
+	getVolumes, err := silk.GetVolumes(timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	getVolumeGroups, err := silk.GetVolumeGroups(timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	volumeGroupNames := map[string]string{}
+	for _, volumeGroup := range getVolumeGroups.Hits {
+		volumeGroupNames[fmt.Sprintf("/volume_groups/%d", volumeGroup.ID)] = volumeGroup.Name
+	}
+
+	getHosts, err := silk.GetHosts(timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hostNames := map[string]string{}
+	for _, host := range getHosts.Hits {
+		hostNames[fmt.Sprintf("/hosts/%d", host.ID)] = host.Name
+	}
+
+	getHostGroups, err := silk.GetHostGroups(timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hostGroupNames := map[string]string{}
+	for _, hostGroup := range getHostGroups.Hits {
+		hostGroupNames[fmt.Sprintf("/host_groups/%d", hostGroup.ID)] = hostGroup.Name
+	}
+
+	getMappings, err := silk.GetHostMappings(timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hostMappings := map[string][]string{}
+	hostGroupMappings := map[string][]string{}
+	for _, mapping := range getMappings {
+		if name, ok := hostNames[mapping.Host.Ref]; ok {
+			hostMappings[mapping.Volume.Ref] = append(hostMappings[mapping.Volume.Ref], name)
+		} else if name, ok := hostGroupNames[mapping.Host.Ref]; ok {
+			hostGroupMappings[mapping.Volume.Ref] = append(hostGroupMappings[mapping.Volume.Ref], name)
+		}
+	}
+
+	volumes := []map[string]interface{}{}
+	for _, volume := range getVolumes.Hits {
+		volumeRef := fmt.Sprintf("/volumes/%d", volume.ID)
+		volumeGroupName := volumeGroupNames[volume.VolumeGroup.Ref]
+
+		if volumeGroupFilter != "" && volumeGroupName != volumeGroupFilter {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(volume.Name) {
+			continue
+		}
+		if _, found := find(hostMappings[volumeRef], hostFilter); hostFilter != "" && !found {
+			continue
+		}
+		if _, found := find(hostGroupMappings[volumeRef], hostGroupFilter); hostGroupFilter != "" && !found {
+			continue
+		}
+		if vmwareSet && volume.VmwareSupport != vmwareFilter {
+			continue
+		}
+		if readOnlySet && volume.ReadOnly != readOnlyFilter {
+			continue
+		}
+
+		volumeGroupID, _ := refID(volume.VolumeGroup.Ref, "volume_groups")
+		description, _ := volume.Description.(string)
+
+		// Sort the mappings to keep the output stable between runs
+		sort.Strings(hostMappings[volumeRef])
+		sort.Strings(hostGroupMappings[volumeRef])
+
+		volumes = append(volumes, map[string]interface{}{
+			"name":               volume.Name,
+			"obj_id":             volume.ID,
+			"size_in_gb":         volume.Size / 1024 / 1024, // Convert to GB
+			"volume_group_id":    volumeGroupID,
+			"volume_group_name":  volumeGroupName,
+			"vmware":             volume.VmwareSupport,
+			"description":        description,
+			"read_only":          volume.ReadOnly,
+			"host_mapping":       hostMappings[volumeRef],
+			"host_group_mapping": hostGroupMappings[volumeRef],
+			"scsi_sn":            volume.ScsiSn,
+		})
+	}
+
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i]["name"].(string) < volumes[j]["name"].(string)
+	})
+
+	if err := d.Set("volumes", volumes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The ID is derived from the filters so the same query always produces the same ID. A boolean filter that is not
+	// set is left empty so it does not produce the same ID as a filter explicitly set to false.
+	filters := []string{
+		volumeGroupFilter,
+		d.Get("name_regex").(string),
+		hostFilter,
+		hostGroupFilter,
+		boolFilter(vmwareFilter, vmwareSet),
+		boolFilter(readOnlyFilter, readOnlySet),
+	}
+	d.SetId(strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(strings.Join(filters, "|")))), 10))
+
+	return diags
+}
+
+// boolFilter returns the value of a boolean filter as a string, or an empty string when the filter is not set
+func boolFilter(value bool, set bool) string {
+	if !set {
+		return ""
+	}
+
+	return strconv.FormatBool(value)
+}
+
+// configuredBool returns the value of a boolean argument and whether it is set in the configuration. Unlike d.Get, the
+// raw configuration tells an argument explicitly set to false apart from an argument that is not set.
+func configuredBool(d *schema.ResourceData, key string) (bool, bool) {
+	value := rawConfigAttribute(d, key)
+	if value.IsNull() || !value.IsKnown() || !value.Type().Equals(cty.Bool) {
+		return false, false
+	}
+
+	return value.True(), true
+}
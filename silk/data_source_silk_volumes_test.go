@@ -0,0 +1,150 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccSilkDataSourceVolumes is the main function that is executed during the test process.
+func TestAccSilkDataSourceVolumes(t *testing.T) {
+
+	// Required Silk Centric Variables.
+	var volumeGroupName = "TerraformTestAccDataVolumesVG"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSilkDataSourceVolumesConfig(volumeGroupName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.silk_volumes.by_volume_group", "volumes.#", "2"),
+					resource.TestCheckResourceAttr("data.silk_volumes.by_volume_group", "volumes.0.name", "TerraformTestAccDataVolumes01"),
+					resource.TestCheckResourceAttr("data.silk_volumes.by_volume_group", "volumes.0.size_in_gb", "10"),
+					resource.TestCheckResourceAttr("data.silk_volumes.by_name_regex", "volumes.#", "1"),
+					resource.TestCheckResourceAttr("data.silk_volumes.by_name_regex", "volumes.0.name", "TerraformTestAccDataVolumes02"),
+					resource.TestCheckResourceAttr("data.silk_volumes.by_vmware", "volumes.#", "1"),
+					resource.TestCheckResourceAttr("data.silk_volumes.by_vmware", "volumes.0.vmware", "false"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSilkDataSourceVolumesConfig returns two silk_volume resources in the same Volume Group and the
+// silk_volumes data sources used to filter them
+func testAccCheckSilkDataSourceVolumesConfig(volumeGroupName string) string {
+	return fmt.Sprintf(`
+	resource "silk_volume_group" "testacc" {
+		name = "%s"
+		quota_in_gb = 40
+		enable_deduplication = true
+		description = "Volume Group used for Terraform silk_volumes data source Acceptance Testing"
+	}
+
+	resource "silk_volume" "testacc01" {
+		name = "TerraformTestAccDataVolumes01"
+		size_in_gb = 10
+		volume_group_name = silk_volume_group.testacc.name
+		vmware = true
+		description = "Volume used for Terraform silk_volumes data source Acceptance Testing"
+		allow_destroy = true
+	}
+
+	resource "silk_volume" "testacc02" {
+		name = "TerraformTestAccDataVolumes02"
+		size_in_gb = 20
+		volume_group_name = silk_volume_group.testacc.name
+		description = "Volume used for Terraform silk_volumes data source Acceptance Testing"
+		allow_destroy = true
+	}
+
+	data "silk_volumes" "by_volume_group" {
+		volume_group_name = silk_volume_group.testacc.name
+
+		depends_on = [silk_volume.testacc01, silk_volume.testacc02]
+	}
+
+	data "silk_volumes" "by_name_regex" {
+		volume_group_name = silk_volume_group.testacc.name
+		name_regex = "02$"
+
+		depends_on = [silk_volume.testacc01, silk_volume.testacc02]
+	}
+
+	data "silk_volumes" "by_vmware" {
+		volume_group_name = silk_volume_group.testacc.name
+		vmware = false
+
+		depends_on = [silk_volume.testacc01, silk_volume.testacc02]
+	}
+	`, volumeGroupName)
+
+}
+
+// TestBoolFilter validates a boolean filter that is not set is not confused with a filter set to false
+func TestBoolFilter(t *testing.T) {
+
+	if boolFilter(false, false) == boolFilter(false, true) {
+		t.Error("expected an unset filter to differ from a filter set to false")
+	}
+	if boolFilter(true, true) != "true" {
+		t.Errorf("expected true, got %s", boolFilter(true, true))
+	}
+}
+
+// TestConfiguredBool validates the vmware filter is read from the raw configuration, so a filter set to false is told
+// apart from a filter that is not set. The Read of the data source only records the filter, without a Silk server.
+func TestConfiguredBool(t *testing.T) {
+
+	testCases := []struct {
+		name          string
+		rawConfig     map[string]cty.Value
+		expectedValue bool
+		expectedSet   bool
+	}{
+		{"not set", map[string]cty.Value{}, false, false},
+		{"false", map[string]cty.Value{"vmware": cty.False}, false, true},
+		{"true", map[string]cty.Value{"vmware": cty.True}, true, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+
+			var value, set bool
+			r := dataSourceSilkVolumes()
+			r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+				value, set = configuredBool(d, "vmware")
+				d.SetId("volumes")
+				return nil
+			}
+
+			config := map[string]interface{}{}
+			for key, v := range tc.rawConfig {
+				config[key] = v.True()
+			}
+			diff, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			diff.RawConfig = testRawConfig(r, tc.rawConfig)
+
+			if _, diags := r.ReadDataApply(context.Background(), diff, nil); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if value != tc.expectedValue || set != tc.expectedSet {
+				t.Errorf("expected the value %t and set %t, got %t and %t", tc.expectedValue, tc.expectedSet, value, set)
+			}
+		})
+	}
+}
@@ -0,0 +1,388 @@
+package silk
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	mathrand "math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxRetryBackoff caps the delay between two attempts of the same request
+const maxRetryBackoff = 30 * time.Second
+
+// gateway is a reverse proxy, listening on the loopback interface, that every request of the Silk SDK is sent
+// through. The SDK creates a new http.Client for each request and does not allow it to be configured, so the gateway
+// is where the provider controls how the requests are made to the Silk server. This includes the TLS settings of the
+// provider, which the SDK does not support since it never verifies the certificate of the server.
+//
+// Each request is sent to the Silk server with the request_timeout of the provider. Requests that fail with a
+// transient error (see retryable) are sent again up to max_retries times, waiting an exponentially increasing,
+// jittered, delay based on retry_backoff between each attempt.
+//
+// At most max_concurrent_requests requests are sent to the Silk server at the same time. The other requests wait for
+// one to complete, which does not include the delay before a retry.
+//
+// Other processes on the same machine can connect to the loopback interface as well, so the gateway only forwards the
+// requests sent to the path of a random token generated for this run (see address). The SDK is the only client that
+// knows it.
+//
+// Each attempt of a request is logged with the logger of the Terraform operation it was made for (see register).
+//
+// Every page of a list is requested (see fetchAll) and the responses to GET requests are cached (see responseCache)
+// unless cache_enabled is false.
+type gateway struct {
+	lastOperation uint64
+	operations    sync.Map
+	logCtx        context.Context
+
+	server       string
+	client       *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+	cache        *responseCache
+	requests     chan struct{}
+
+	listener net.Listener
+	token    string
+
+	randMu sync.Mutex
+	rand   *mathrand.Rand
+}
+
+// gatewayResponse holds a response of the Silk server, read in full so it can be inspected before being returned to
+// the SDK.
+type gatewayResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// newGateway creates the gateway for the Silk server in the provided Config. start must be called before it can be
+// used.
+func newGateway(c *Config) (*gateway, error) {
+
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var cache *responseCache
+	if c.CacheEnabled {
+		cache = newResponseCache()
+	}
+
+	return &gateway{
+		server: c.Server,
+		client: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+			Timeout: time.Duration(c.RequestTimeout) * time.Second,
+		},
+		maxRetries:   c.MaxRetries,
+		retryBackoff: time.Duration(c.RetryBackoff) * time.Second,
+		cache:        cache,
+		requests:     make(chan struct{}, maxConcurrentRequests(c)),
+		logCtx:       context.Background(),
+		rand:         mathrand.New(mathrand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+// start listens on a random port of the loopback interface and serves the requests of the SDK in the background. The
+// SDK only connects over HTTPS and does not verify the certificate, so the gateway uses a self-signed certificate
+// generated for this run.
+func (g *gateway) start() error {
+
+	certificate, err := selfSignedCertificate()
+	if err != nil {
+		return err
+	}
+
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return fmt.Errorf("Unable to start the Silk API gateway: %s", err)
+	}
+	g.token = hex.EncodeToString(token)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{certificate}})
+	if err != nil {
+		return fmt.Errorf("Unable to start the Silk API gateway: %s", err)
+	}
+	g.listener = listener
+
+	go http.Serve(listener, g)
+
+	return nil
+}
+
+// close stops the gateway from accepting requests
+func (g *gateway) close() error {
+	return g.listener.Close()
+}
+
+// address returns the host:port, followed by the path of the token of this run, the SDK should use as the Silk server
+func (g *gateway) address() string {
+	return g.listener.Addr().String() + "/" + g.token
+}
+
+// authorize returns the URI of a request of the SDK without the path of the token. ok is false when the request was
+// not sent to the path of the token.
+func (g *gateway) authorize(uri string) (string, bool) {
+
+	prefix := "/" + g.token + "/"
+	if len(uri) < len(prefix) || subtle.ConstantTimeCompare([]byte(uri[:len(prefix)]), []byte(prefix)) != 1 {
+		return "", false
+	}
+
+	return uri[len(prefix)-1:], true
+}
+
+// ServeHTTP forwards a request of the SDK to the Silk server and writes the response back to the SDK.
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	uri, ok := g.authorize(r.URL.RequestURI())
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+
+	operation, uri := g.operation(uri)
+	ctx := g.logContext(r.Context(), operation, uri, body)
+
+	response, err := g.forward(ctx, r.Method, uri, r.Header, body)
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+
+	for key, values := range response.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(response.StatusCode)
+	w.Write(response.Body)
+}
+
+// forward sends a request of the SDK to the Silk server. Every page of the response to a GET request is requested (see
+// fetchAll) and, when the cache is enabled, the response is cached. Any other request invalidates the cache.
+func (g *gateway) forward(ctx context.Context, method, uri string, header http.Header, body []byte) (*gatewayResponse, error) {
+
+	if method != http.MethodGet {
+		if g.cache != nil {
+			g.cache.invalidate()
+			defer g.cache.invalidate()
+		}
+
+		return g.do(ctx, method, uri, header, body)
+	}
+
+	if g.cache == nil {
+		return g.fetchAll(ctx, uri, header)
+	}
+
+	return g.cache.get(ctx, uri, header, func(ctx context.Context) (*gatewayResponse, error) {
+		return g.fetchAll(ctx, uri, header)
+	})
+}
+
+// do sends the request to the Silk server, retrying it while it fails with a transient error.
+func (g *gateway) do(ctx context.Context, method, uri string, header http.Header, body []byte) (*gatewayResponse, error) {
+
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		response, err := g.send(ctx, method, uri, header, body)
+		logRequest(ctx, method, uri, header, body, attempt, start, response, err)
+		if attempt >= g.maxRetries || ctx.Err() != nil || !retryable(method, response, err) {
+			if err != nil && attempt != 0 {
+				err = fmt.Errorf("%w (after %d attempts)", err, attempt+1)
+			}
+			return response, err
+		}
+
+		select {
+		case <-time.After(g.backoff(attempt)):
+		case <-ctx.Done():
+			return response, err
+		}
+	}
+}
+
+// send makes a single attempt of the request to the Silk server
+func (g *gateway) send(ctx context.Context, method, uri string, header http.Header, body []byte) (*gatewayResponse, error) {
+
+	request, err := http.NewRequest(method, fmt.Sprintf("https://%s%s", g.server, uri), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request = request.WithContext(ctx)
+
+	select {
+	case g.requests <- struct{}{}:
+		defer func() { <-g.requests }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	for _, key := range []string{"Authorization", "Content-Type"} {
+		if value := header.Get(key); value != "" {
+			request.Header.Set(key, value)
+		}
+	}
+
+	apiRequest, err := g.client.Do(request)
+	if err != nil {
+		if err, ok := err.(net.Error); ok && err.Timeout() {
+			return nil, fmt.Errorf("Unable to establish a connection to the Silk server: %w", err)
+		}
+		return nil, err
+	}
+	defer apiRequest.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(apiRequest.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	responseHeader := http.Header{}
+	if contentType := apiRequest.Header.Get("Content-Type"); contentType != "" {
+		responseHeader.Set("Content-Type", contentType)
+	}
+
+	return &gatewayResponse{StatusCode: apiRequest.StatusCode, Header: responseHeader, Body: responseBody}, nil
+}
+
+// maxConcurrentRequests returns the number of requests that can be sent to the Silk server at the same time
+func maxConcurrentRequests(c *Config) int {
+	if c.MaxConcurrentRequests < 1 {
+		return 1
+	}
+
+	return c.MaxConcurrentRequests
+}
+
+// retryable returns true when the request failed with a transient error: the Silk server could not be reached (ex.
+// during a c-node failover), it returned a server error (5xx), it reported a conflict with another operation (409),
+// or it asked the client to slow down (429). A certificate that can not be verified is not transient.
+//
+// Only the GET requests are retried on every transient error. A POST, PATCH, or DELETE that reached the Silk server
+// may have been applied even though it failed, so it is only retried when it was never sent (the connection could
+// not be established) or was rejected before being processed: the Silk server was unavailable (503), the object was
+// busy with another operation (409), or the client was asked to slow down (429).
+func retryable(method string, response *gatewayResponse, err error) bool {
+	if err != nil {
+		if certificateError(err) {
+			return false
+		}
+		return idempotent(method) || dialError(err)
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusConflict:
+		return true
+	}
+
+	return idempotent(method) && response.StatusCode >= 500
+}
+
+// idempotent returns true for the methods whose requests can be sent again without changing the Silk server
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// dialError returns true when the connection to the Silk server could not be established, so the request was never
+// sent
+func dialError(err error) bool {
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// certificateError returns true when the certificate of the Silk server could not be verified
+func certificateError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+
+	return errors.As(err, &unknownAuthority) || errors.As(err, &hostname) || errors.As(err, &invalid)
+}
+
+// backoff returns the delay before the next attempt. The delay doubles with each attempt, up to maxRetryBackoff, and
+// a random jitter of up to half of it is applied so concurrent requests do not retry in lockstep.
+func (g *gateway) backoff(attempt int) time.Duration {
+
+	delay := g.retryBackoff
+	for i := 0; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+
+	half := int64(delay / 2)
+	if half == 0 {
+		return delay
+	}
+
+	g.randMu.Lock()
+	defer g.randMu.Unlock()
+
+	return time.Duration(half + g.rand.Int63n(half+1))
+}
+
+// writeGatewayError returns the error to the SDK in the format of the Silk API so the SDK returns it as is
+func writeGatewayError(w http.ResponseWriter, err error) {
+	body, _ := json.Marshal(map[string]interface{}{"error_msg": err.Error()})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadGateway)
+	w.Write(body)
+}
+
+// selfSignedCertificate generates the certificate the gateway presents to the SDK
+func selfSignedCertificate() (tls.Certificate, error) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "terraform-provider-silk"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
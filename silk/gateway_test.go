@@ -0,0 +1,585 @@
+package silk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// testGateway starts a gateway in front of the provided handler, which stands in for the Silk server
+func testGateway(t *testing.T, handler http.HandlerFunc) *gateway {
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	g, err := testGatewayConfig(server, &Config{Insecure: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() { g.close() })
+
+	return g
+}
+
+// testGatewayConfig starts a gateway in front of the provided server with the provided Config
+func testGatewayConfig(server *httptest.Server, c *Config) (*gateway, error) {
+
+	c.Server = server.Listener.Addr().String()
+	c.RequestTimeout = 5
+	c.MaxRetries = 3
+	c.RetryBackoff = 1
+
+	g, err := newGateway(c)
+	if err != nil {
+		return nil, err
+	}
+	g.retryBackoff = time.Millisecond
+
+	if err := g.start(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func TestGatewayRetry(t *testing.T) {
+
+	testCases := []struct {
+		name             string
+		method           string
+		statusCodes      []int
+		expectedStatus   int
+		expectedAttempts int32
+	}{
+		{"success", "GET", []int{200}, 200, 1},
+		{"server error then success", "GET", []int{503, 500, 200}, 200, 3},
+		{"conflict then success", "GET", []int{409, 200}, 200, 2},
+		{"retries exhausted", "GET", []int{503, 503, 503, 503, 503}, 503, 4},
+		{"client error is not retried", "GET", []int{400, 200}, 400, 1},
+		{"not found is not retried", "GET", []int{404, 200}, 404, 1},
+		{"create unavailable is retried", "POST", []int{503, 200}, 200, 2},
+		{"update conflict is retried", "PATCH", []int{409, 200}, 200, 2},
+		{"create server error is not retried", "POST", []int{502, 200}, 502, 1},
+		{"delete server error is not retried", "DELETE", []int{500, 200}, 500, 1},
+		{"delete conflict is retried", "DELETE", []int{409, 200}, 200, 2},
+		{"create too many requests then success", "POST", []int{429, 200}, 200, 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts int32
+			g := testGateway(t, func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+				w.WriteHeader(tc.statusCodes[attempt-1])
+				w.Write([]byte(`{}`))
+			})
+
+			response, err := g.do(context.Background(), tc.method, "/api/v2/volumes", http.Header{}, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if response.StatusCode != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, response.StatusCode)
+			}
+
+			if attempts != tc.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", tc.expectedAttempts, attempts)
+			}
+		})
+	}
+}
+
+// TestRetryableError validates a request that failed without a response is only retried when it is a GET or the
+// connection to the Silk server could not be established
+func TestRetryableError(t *testing.T) {
+
+	dial := &url.Error{Op: "Post", URL: "https://silk", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	read := &url.Error{Op: "Post", URL: "https://silk", Err: &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}}
+
+	testCases := []struct {
+		name     string
+		method   string
+		err      error
+		expected bool
+	}{
+		{"get dial error", "GET", dial, true},
+		{"get read error", "GET", read, true},
+		{"create dial error", "POST", dial, true},
+		{"create read error", "POST", read, false},
+		{"certificate error", "GET", x509.UnknownAuthorityError{}, false},
+	}
+
+	for _, tc := range testCases {
+		if retry := retryable(tc.method, nil, tc.err); retry != tc.expected {
+			t.Errorf("%s: expected %t, got %t", tc.name, tc.expected, retry)
+		}
+	}
+}
+
+func TestGatewayBackoff(t *testing.T) {
+
+	g, err := newGateway(&Config{RetryBackoff: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := map[int]time.Duration{
+		0:  time.Second,
+		1:  2 * time.Second,
+		2:  4 * time.Second,
+		10: maxRetryBackoff,
+	}
+
+	for attempt, delay := range testCases {
+		for i := 0; i < 100; i++ {
+			backoff := g.backoff(attempt)
+			if backoff < delay/2 || backoff > delay {
+				t.Fatalf("attempt %d: expected a backoff between %s and %s, got %s", attempt, delay/2, delay, backoff)
+			}
+		}
+	}
+}
+
+// TestGatewaySDK validates the requests of the SDK are forwarded to the Silk server, along with their credentials
+func TestGatewaySDK(t *testing.T) {
+
+	var attempts int32
+	g := testGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.URL.Path != "/api/v2/system/state" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"hits": [{"system_version": "6.0.0"}]}`))
+	})
+
+	silk := silksdp.Connect(g.address(), "admin", "secret")
+
+	response, err := silk.Get("/system/state", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	hits := response.(map[string]interface{})["hits"].([]interface{})
+	if version := hits[0].(map[string]interface{})["system_version"]; version != "6.0.0" {
+		t.Errorf("expected the system_version 6.0.0, got %v", version)
+	}
+
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+// TestGatewayToken validates the gateway only forwards the requests sent to the path of the token of the run, and
+// stops accepting requests once it is closed
+func TestGatewayToken(t *testing.T) {
+
+	var requests int32
+	g := testGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{}`))
+	})
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	host := g.listener.Addr().String()
+
+	testCases := []struct {
+		name           string
+		url            string
+		expectedStatus int
+	}{
+		{"token", fmt.Sprintf("https://%s/api/v2/volumes", g.address()), http.StatusOK},
+		{"no token", fmt.Sprintf("https://%s/api/v2/volumes", host), http.StatusForbidden},
+		{"wrong token", fmt.Sprintf("https://%s/%s/api/v2/volumes", host, strings.Repeat("0", len(g.token))), http.StatusForbidden},
+		{"token without a path", fmt.Sprintf("https://%s", g.address()), http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		response, err := client.Get(tc.url)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", tc.name, err)
+		}
+		response.Body.Close()
+
+		if response.StatusCode != tc.expectedStatus {
+			t.Errorf("%s: expected status %d, got %d", tc.name, tc.expectedStatus, response.StatusCode)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected 1 request to be forwarded, got %d", requests)
+	}
+
+	if err := g.close(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	client.CloseIdleConnections()
+	if _, err := client.Get(fmt.Sprintf("https://%s/api/v2/volumes", g.address())); err == nil {
+		t.Error("expected the closed gateway to refuse the connection")
+	}
+}
+
+func TestGatewayCache(t *testing.T) {
+
+	testCases := []struct {
+		name             string
+		cacheEnabled     bool
+		requests         []string
+		expectedRequests int32
+	}{
+		{"cached", true, []string{"GET /volumes", "GET /volumes", "GET /hosts", "GET /volumes"}, 2},
+		{"disabled", false, []string{"GET /volumes", "GET /volumes", "GET /hosts", "GET /volumes"}, 4},
+		{"invalidated by a create", true, []string{"GET /volumes", "POST /volumes", "GET /volumes"}, 3},
+		{"invalidated by an update", true, []string{"GET /volumes", "PATCH /volumes/1", "GET /volumes"}, 3},
+		{"invalidated by a delete", true, []string{"GET /volumes", "DELETE /volumes/1", "GET /volumes"}, 3},
+		{"errors are not cached", true, []string{"GET /missing", "GET /missing"}, 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+				if r.URL.Path == "/api/v2/missing" {
+					w.WriteHeader(http.StatusNotFound)
+				}
+				w.Write([]byte(`{"hits": []}`))
+			}))
+			defer server.Close()
+
+			g, err := testGatewayConfig(server, &Config{Insecure: true, CacheEnabled: tc.cacheEnabled})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			for _, request := range tc.requests {
+				fields := strings.Fields(request)
+				if _, err := g.forward(context.Background(), fields[0], "/api/v2"+fields[1], http.Header{}, nil); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			if requests != tc.expectedRequests {
+				t.Errorf("expected %d requests to the Silk server, got %d", tc.expectedRequests, requests)
+			}
+		})
+	}
+}
+
+// TestGatewayCacheConcurrency validates concurrent identical requests are coalesced into a single request and that a
+// response requested before a change is not cached.
+func TestGatewayCacheConcurrency(t *testing.T) {
+
+	var requests int32
+	release := make(chan struct{})
+	g := testGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Write([]byte(`{"hits": []}`))
+	})
+	g.cache = newResponseCache()
+
+	get := func(wg *sync.WaitGroup) {
+		defer wg.Done()
+		if _, err := g.forward(context.Background(), "GET", "/api/v2/volumes", http.Header{}, nil); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+
+	// waitForRequests waits for the Silk server to receive the expected number of requests
+	waitForRequests := func(expected int32) {
+		for atomic.LoadInt32(&requests) < expected {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		go get(&wg)
+	}
+	waitForRequests(1)
+	time.Sleep(50 * time.Millisecond)
+	release <- struct{}{}
+	wg.Wait()
+
+	if requests != 1 {
+		t.Errorf("expected the requests to be coalesced into 1 request, got %d", requests)
+	}
+
+	// The inventory changes while the request is in flight, so its response is not cached
+	g.cache.invalidate()
+	wg.Add(1)
+	go get(&wg)
+	waitForRequests(2)
+	g.cache.invalidate()
+	release <- struct{}{}
+	wg.Wait()
+
+	close(release)
+	wg.Add(1)
+	get(&wg)
+
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+}
+
+// TestGatewayCacheCancel validates a caller that is canceled stops waiting for a coalesced request, and the request
+// still completes for the other callers.
+func TestGatewayCacheCancel(t *testing.T) {
+
+	var requests int32
+	release := make(chan struct{})
+	g := testGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Write([]byte(`{"hits": []}`))
+	})
+	g.cache = newResponseCache()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error, 1)
+	go func() {
+		_, err := g.forward(ctx, "GET", "/api/v2/volumes", http.Header{}, nil)
+		canceled <- err
+	}()
+	for atomic.LoadInt32(&requests) < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	completed := make(chan error, 1)
+	go func() {
+		_, err := g.forward(context.Background(), "GET", "/api/v2/volumes", http.Header{}, nil)
+		completed <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	if err := <-canceled; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the canceled caller to return %s, got %v", context.Canceled, err)
+	}
+
+	close(release)
+	if err := <-completed; err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("expected the requests to be coalesced into 1 request, got %d", requests)
+	}
+}
+
+func TestGatewayTLS(t *testing.T) {
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/client" && len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+		w.Write([]byte(`{}`))
+	}))
+
+	// The server verifies the client certificate, when one is presented, against the client CA
+	clientCertificate, clientKey, clientCA := testClientCertificate(t)
+	server.TLS = &tls.Config{ClientAuth: tls.VerifyClientCertIfGiven, ClientCAs: clientCA}
+	server.StartTLS()
+	defer server.Close()
+
+	// The httptest certificate is valid for example.com and 127.0.0.1
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	serverCAFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := ioutil.WriteFile(serverCAFile, []byte(serverCA), 0600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		name      string
+		config    Config
+		expectErr bool
+	}{
+		{"unknown authority", Config{}, true},
+		{"ca_bundle content", Config{CABundle: serverCA}, false},
+		{"ca_bundle path", Config{CABundle: serverCAFile}, false},
+		{"tls_server_name in the certificate", Config{CABundle: serverCA, TLSServerName: "example.com"}, false},
+		{"tls_server_name not in the certificate", Config{CABundle: serverCA, TLSServerName: "silk.example.net"}, true},
+		{"insecure", Config{Insecure: true}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := testGatewayConfig(server, &tc.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			_, err = g.do(context.Background(), "GET", "/api/v2/system/state", http.Header{}, nil)
+			if tc.expectErr && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			// Certificate errors are not retried
+			if tc.expectErr && strings.Contains(err.Error(), "attempts") {
+				t.Errorf("expected a single attempt, got %s", err)
+			}
+		})
+	}
+
+	// The client certificate is presented to the server
+	for _, c := range []struct {
+		config         Config
+		expectedStatus int
+	}{
+		{Config{CABundle: serverCA}, http.StatusUnauthorized},
+		{Config{CABundle: serverCA, ClientCertificate: clientCertificate, ClientKey: clientKey}, http.StatusOK},
+	} {
+		g, err := testGatewayConfig(server, &c.config)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		response, err := g.do(context.Background(), "GET", "/api/v2/client", http.Header{}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if response.StatusCode != c.expectedStatus {
+			t.Errorf("expected status %d, got %d", c.expectedStatus, response.StatusCode)
+		}
+	}
+
+	for _, c := range []Config{
+		{CABundle: "-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----"},
+		{CABundle: filepath.Join(t.TempDir(), "missing.pem")},
+		{ClientCertificate: clientCertificate, ClientKey: serverCA},
+	} {
+		if _, err := c.tlsConfig(); err == nil {
+			t.Errorf("expected an error for %+v", c)
+		}
+	}
+}
+
+// testClientCertificate returns a PEM encoded client certificate and key along with a pool containing the
+// certificate
+func testClientCertificate(t *testing.T) (string, string, *x509.CertPool) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	certificate, _ := x509.ParseCertificate(der)
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})),
+		pool
+}
+
+func TestGatewayMaxConcurrentRequests(t *testing.T) {
+
+	var running, maxRunning int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	g, err := testGatewayConfig(server, &Config{Insecure: true, MaxConcurrentRequests: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := g.forward(context.Background(), "POST", "/api/v2/mappings", http.Header{}, nil); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxRunning != 3 {
+		t.Errorf("expected at most 3 concurrent requests, got %d", maxRunning)
+	}
+
+	// A request waiting for its turn gives up when its context is done
+	g.requests <- struct{}{}
+	g.requests <- struct{}{}
+	g.requests <- struct{}{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := g.do(ctx, "GET", "/api/v2/volumes", http.Header{}, nil); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,88 @@
+package silk
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// mutexKV holds a mutex for each key. The Silk server rejects, with a conflict, concurrent changes to the mappings and
+// members of the same Host, Host Group, or Volume Group, so the resources lock the objects they change to serialize
+// those operations while Terraform applies the other resources in parallel.
+type mutexKV struct {
+	mu    sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+// newMutexKV returns an empty mutexKV
+func newMutexKV() *mutexKV {
+	return &mutexKV{store: map[string]*sync.Mutex{}}
+}
+
+// get returns the mutex of the key, creating it if needed
+func (m *mutexKV) get(key string) *sync.Mutex {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+
+	return mutex
+}
+
+// lock locks the provided keys and returns a function that unlocks them. The keys are locked in sorted order so two
+// operations that lock the same objects can not deadlock.
+func (m *mutexKV) lock(keys ...string) func() {
+
+	keys = unique(keys)
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		m.get(key).Lock()
+	}
+
+	return func() {
+		for i := len(keys) - 1; i >= 0; i-- {
+			m.get(keys[i]).Unlock()
+		}
+	}
+}
+
+// The lock keys of the objects whose mappings or members are changed by the resources
+func hostLockKey(name string) string        { return "host/" + name }
+func hostGroupLockKey(name string) string   { return "host_group/" + name }
+func volumeGroupLockKey(name string) string { return "volume_group/" + name }
+
+// lockKeys returns the lock keys of the names in the current and new values of the list attribute. The list holds
+// either names or blocks with a name (ex. host_mapping).
+func lockKeys(d *schema.ResourceData, attribute string, lockKey func(string) string) []string {
+
+	c, n := d.GetChange(attribute)
+
+	keys := []string{}
+	for _, list := range []interface{}{c, n} {
+		values, _ := list.([]interface{})
+		for _, value := range values {
+			switch value := value.(type) {
+			case string:
+				keys = append(keys, lockKey(value))
+			case map[string]interface{}:
+				if name, ok := value["name"].(string); ok {
+					keys = append(keys, lockKey(name))
+				}
+			}
+		}
+	}
+
+	return keys
+}
+
+// mappingLockKeys returns the lock keys of the Hosts and Host Groups in the host_mapping and host_group_mapping
+// attributes of a Volume or View
+func mappingLockKeys(d *schema.ResourceData) []string {
+	return append(lockKeys(d, "host_mapping", hostLockKey), lockKeys(d, "host_group_mapping", hostGroupLockKey)...)
+}
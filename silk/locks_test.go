@@ -0,0 +1,103 @@
+package silk
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMutexKV(t *testing.T) {
+
+	locks := newMutexKV()
+
+	// Operations on the same object run one at a time
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := locks.lock(hostLockKey("host-1"))
+			defer unlock()
+
+			if n := atomic.AddInt32(&running, 1); n > atomic.LoadInt32(&maxRunning) {
+				atomic.StoreInt32(&maxRunning, n)
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}()
+	}
+	wg.Wait()
+
+	if maxRunning != 1 {
+		t.Errorf("expected 1 operation at a time, got %d", maxRunning)
+	}
+
+	// Operations that lock the same objects in a different order do not deadlock
+	done := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				locks.lock(hostLockKey("host-1"), hostGroupLockKey("host-group-1"))()
+			}()
+			go func() {
+				defer wg.Done()
+				locks.lock(hostGroupLockKey("host-group-1"), hostLockKey("host-1"), hostLockKey("host-1"))()
+			}()
+		}
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the operations deadlocked")
+	}
+
+	// Operations on different objects run at the same time
+	unlock := locks.lock(hostLockKey("host-1"))
+	defer unlock()
+	locks.lock(hostLockKey("host-2"), volumeGroupLockKey("host-1"))()
+}
+
+func TestLockKeys(t *testing.T) {
+
+	d := schema.TestResourceDataRaw(t, resourceSilkVolume().Schema, map[string]interface{}{
+		"name":              "volume-1",
+		"volume_group_name": "volume-group-1",
+		"host_mapping": []interface{}{
+			map[string]interface{}{"name": "host-1"},
+			map[string]interface{}{"name": "host-2", "lun": 3},
+		},
+		"host_group_mapping": []interface{}{
+			map[string]interface{}{"name": "host-group-1"},
+		},
+	})
+
+	keys := volumeLockKeys(d)
+	sort.Strings(keys)
+
+	expected := []string{"host/host-1", "host/host-2", "host_group/host-group-1", "volume_group/volume-group-1"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected the lock keys %v, got %v", expected, keys)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceSilkHostGroup().Schema, map[string]interface{}{
+		"name":         "host-group-1",
+		"host_mapping": []interface{}{"host-1", "host-2"},
+	})
+
+	expected = []string{"host/host-1", "host/host-2"}
+	if keys := lockKeys(d, "host_mapping", hostLockKey); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected the lock keys %v, got %v", expected, keys)
+	}
+}
@@ -0,0 +1,241 @@
+package silk
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// redacted replaces the credentials in the logs
+const redacted = "[REDACTED]"
+
+// operationPathPrefix is the path the SDK requests of an operation are sent to (see gateway.register)
+const operationPathPrefix = "/operations/"
+
+// operation is a Terraform operation (ex. the create of a silk_volume) whose requests are sent through the gateway.
+// The context of the operation holds the Terraform logger, with the fields of the operation (ex. tf_req_id and
+// tf_resource_type), that the requests are logged with.
+type operation struct {
+	ctx        context.Context
+	objectName string
+}
+
+// credentials returns the Silk SDK credentials an operation uses. The requests of the operation are logged with its
+// context and the name of the object it operates on.
+func (c *Client) credentials(ctx context.Context, d resourceGetter) *silksdp.Credentials {
+	return &silksdp.Credentials{
+		Server:   c.Server + c.gateway.register(ctx, objectName(d)),
+		Username: c.Username,
+		Password: c.Password,
+	}
+}
+
+// objectName returns the name of the object a resource or data source operates on. Mappings are named
+// <volume name>:<host or host group name>, like their import ID. Before an import, the ID holds the name.
+func objectName(d resourceGetter) string {
+	if name, ok := d.GetOk("name"); ok {
+		return name.(string)
+	}
+
+	if volumeName, ok := d.GetOk("volume_name"); ok {
+		for _, key := range []string{"host_name", "host_group_name"} {
+			if name, ok := d.GetOk(key); ok {
+				return fmt.Sprintf("%s:%s", volumeName, name)
+			}
+		}
+	}
+
+	return d.Id()
+}
+
+// register records the operation and returns the path, appended to the address of the gateway, its requests are sent
+// to. The operation is removed when its context is done.
+func (g *gateway) register(ctx context.Context, objectName string) string {
+
+	done := ctx.Done()
+	if done == nil {
+		return ""
+	}
+
+	id := strconv.FormatUint(atomic.AddUint64(&g.lastOperation, 1), 10)
+	g.operations.Store(id, &operation{ctx: ctx, objectName: objectName})
+
+	go func() {
+		<-done
+		g.operations.Delete(id)
+	}()
+
+	return operationPathPrefix + id
+}
+
+// operation returns the operation a request of the SDK was sent for, along with the URI of the request without the
+// path of the operation. A request that was not sent for an operation is logged with the context the provider was
+// configured with.
+func (g *gateway) operation(uri string) (*operation, string) {
+
+	if strings.HasPrefix(uri, operationPathPrefix) {
+		parts := strings.SplitN(strings.TrimPrefix(uri, operationPathPrefix), "/", 2)
+		if len(parts) == 2 {
+			uri = "/" + parts[1]
+			if o, ok := g.operations.Load(parts[0]); ok {
+				return o.(*operation), uri
+			}
+		}
+	}
+
+	return &operation{ctx: g.logCtx}, uri
+}
+
+// logContext returns the context a request is sent with. It is canceled with the request of the SDK, and the requests
+// to the Silk server are logged with the logger of the operation along with the correlation ID and the object name.
+func (g *gateway) logContext(ctx context.Context, o *operation, uri string, body []byte) context.Context {
+
+	ctx = logValuesContext{Context: ctx, values: o.ctx}
+
+	objectName := o.objectName
+	if objectName == "" {
+		objectName = requestObjectName(uri, body)
+	}
+
+	ctx = tflog.SetField(ctx, "sdp_correlation_id", correlationID())
+	ctx = tflog.SetField(ctx, "sdp_object_name", objectName)
+
+	return ctx
+}
+
+// logValuesContext is canceled with its Context, and holds the values (i.e the Terraform logger) of another context.
+type logValuesContext struct {
+	context.Context
+	values context.Context
+}
+
+// Value returns the value of the key in the values context
+func (c logValuesContext) Value(key interface{}) interface{} {
+	return c.values.Value(key)
+}
+
+// logRequest logs a single attempt of a request to the Silk server. The bodies of the request and the response are
+// logged at the TRACE level, with the credentials redacted.
+func logRequest(ctx context.Context, method, uri string, header http.Header, body []byte, attempt int, start time.Time, response *gatewayResponse, err error) {
+
+	fields := map[string]interface{}{
+		"sdp_method":      method,
+		"sdp_endpoint":    uri,
+		"sdp_attempt":     attempt + 1,
+		"sdp_duration_ms": time.Since(start).Milliseconds(),
+	}
+
+	if len(body) != 0 {
+		tflog.Trace(ctx, "Silk API request body", map[string]interface{}{
+			"sdp_method":         method,
+			"sdp_endpoint":       uri,
+			"sdp_request_header": redactHeader(header),
+			"sdp_request_body":   redactBody(body),
+		})
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Silk API request failed", fields)
+		return
+	}
+
+	fields["sdp_status_code"] = response.StatusCode
+	tflog.Debug(ctx, "Silk API request", fields)
+
+	tflog.Trace(ctx, "Silk API response body", map[string]interface{}{
+		"sdp_method":        method,
+		"sdp_endpoint":      uri,
+		"sdp_status_code":   response.StatusCode,
+		"sdp_response_body": redactBody(response.Body),
+	})
+}
+
+// redactHeader returns the header, as a string, with the credentials redacted
+func redactHeader(header http.Header) string {
+	values := []string{}
+	for key := range header {
+		value := header.Get(key)
+		if strings.EqualFold(key, "Authorization") || strings.EqualFold(key, "Cookie") {
+			value = redacted
+		}
+		values = append(values, fmt.Sprintf("%s: %s", key, value))
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// redactBody returns the JSON body, as a string, with the value of every password or secret redacted. A body that is
+// not JSON is returned as is.
+func redactBody(body []byte) string {
+	var value interface{}
+	if json.Unmarshal(body, &value) != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(redacted)
+}
+
+// redactValue redacts the value of every key of the JSON value that holds a password or a secret
+func redactValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			k := strings.ToLower(key)
+			if strings.Contains(k, "password") || strings.Contains(k, "secret") {
+				value[key] = redacted
+			} else {
+				value[key] = redactValue(v)
+			}
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = redactValue(v)
+		}
+	}
+
+	return value
+}
+
+// requestObjectName returns the name of the object a request is for, from its name query parameters or its body
+func requestObjectName(uri string, body []byte) string {
+	if requestURL, err := url.Parse(uri); err == nil {
+		for _, key := range []string{"name", "name__in", "name__contains"} {
+			if name := requestURL.Query().Get(key); name != "" {
+				return name
+			}
+		}
+	}
+
+	var object struct {
+		Name string `json:"name"`
+	}
+	json.Unmarshal(body, &object)
+
+	return object.Name
+}
+
+// correlationID returns a random ID that identifies a request of the SDK, along with its retries and pages, in the
+// logs
+func correlationID() string {
+	id := make([]byte, 8)
+	rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
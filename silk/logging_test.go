@@ -0,0 +1,174 @@
+package silk
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+func TestRedactBody(t *testing.T) {
+
+	testCases := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"no credentials", `{"name":"host-1"}`, `{"name":"host-1"}`},
+		{"password", `{"name":"admin","password":"hunter2"}`, `{"name":"admin","password":"[REDACTED]"}`},
+		{"nested secret", `{"hits":[{"chap_secret":"hunter2","name":"host-1"}]}`, `{"hits":[{"chap_secret":"[REDACTED]","name":"host-1"}]}`},
+		{"case insensitive", `{"Password":{"value":"hunter2"}}`, `{"Password":"[REDACTED]"}`},
+		{"not JSON", `<html></html>`, `<html></html>`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if redactedBody := redactBody([]byte(tc.body)); redactedBody != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, redactedBody)
+			}
+		})
+	}
+}
+
+func TestObjectName(t *testing.T) {
+
+	testCases := []struct {
+		name     string
+		d        *schema.ResourceData
+		expected string
+	}{
+		{"name", schema.TestResourceDataRaw(t, resourceSilkHost().Schema, map[string]interface{}{"name": "host-1"}), "host-1"},
+		{"host mapping", schema.TestResourceDataRaw(t, resourceSilkHostVolumeMapping().Schema, map[string]interface{}{"volume_name": "volume-1", "host_name": "host-1"}), "volume-1:host-1"},
+		{"host group mapping", schema.TestResourceDataRaw(t, resourceSilkHostGroupVolumeMapping().Schema, map[string]interface{}{"volume_name": "volume-1", "host_group_name": "host-group-1"}), "volume-1:host-group-1"},
+		{"no name", schema.TestResourceDataRaw(t, dataSourceSilkHosts().Schema, map[string]interface{}{}), "hosts"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.d.Id() == "" {
+				tc.d.SetId("hosts")
+			}
+
+			if name := objectName(tc.d); name != tc.expected {
+				t.Errorf("expected the object name %s, got %s", tc.expected, name)
+			}
+		})
+	}
+}
+
+// TestGatewayLogging validates the requests of an operation are logged with the logger of the operation, along with
+// the fields that identify the request, and that the credentials are never logged.
+func TestGatewayLogging(t *testing.T) {
+
+	var attempts int32
+	g := testGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id": 1, "name": "host-1", "password": "hunter2"}`))
+	})
+
+	var providerOutput, operationOutput bytes.Buffer
+	g.logCtx = tflogtest.RootLogger(context.Background(), &providerOutput)
+
+	ctx, cancel := context.WithCancel(tflogtest.RootLogger(context.Background(), &operationOutput))
+	defer cancel()
+	ctx = tflog.SetField(ctx, "tf_resource_type", "silk_host")
+
+	client := &Client{Credentials: silksdp.Connect(g.address(), "admin", "secret"), gateway: g}
+	d := schema.TestResourceDataRaw(t, resourceSilkHost().Schema, map[string]interface{}{"name": "host-1"})
+
+	silk := client.credentials(ctx, d)
+	if _, err := silk.Post("/hosts", map[string]interface{}{"name": "host-1", "password": "hunter2"}, 5); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&operationOutput)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	requests := []map[string]interface{}{}
+	bodies := 0
+	for _, entry := range entries {
+		switch entry["@message"] {
+		case "Silk API request":
+			requests = append(requests, entry)
+		case "Silk API request body", "Silk API response body":
+			bodies++
+			if entry["@level"] != "trace" {
+				t.Errorf("expected the bodies to be logged at the trace level, got %v", entry["@level"])
+			}
+		}
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 attempts to be logged, got %d", len(requests))
+	}
+	if bodies == 0 {
+		t.Error("expected the bodies to be logged")
+	}
+
+	for i, request := range requests {
+		expected := map[string]interface{}{
+			"@level":           "debug",
+			"tf_resource_type": "silk_host",
+			"sdp_method":       "POST",
+			"sdp_endpoint":     "/api/v2/hosts",
+			"sdp_object_name":  "host-1",
+			"sdp_attempt":      float64(i + 1),
+		}
+		for key, value := range expected {
+			if request[key] != value {
+				t.Errorf("attempt %d: expected %s to be %v, got %v", i+1, key, value, request[key])
+			}
+		}
+
+		for _, key := range []string{"sdp_duration_ms", "sdp_status_code", "sdp_correlation_id"} {
+			if _, ok := request[key]; !ok {
+				t.Errorf("attempt %d: expected the field %s", i+1, key)
+			}
+		}
+	}
+
+	if requests[0]["sdp_correlation_id"] != requests[1]["sdp_correlation_id"] {
+		t.Error("expected the retry to have the correlation ID of the request")
+	}
+
+	// The credentials are redacted
+	output := operationOutput.String()
+	for _, credential := range []string{"hunter2", "secret", base64.StdEncoding.EncodeToString([]byte("admin:secret"))} {
+		if strings.Contains(output, credential) {
+			t.Errorf("expected %s to be redacted from the logs", credential)
+		}
+	}
+
+	// A request that is not made for an operation is logged with the logger of the provider
+	if _, err := silksdp.Connect(g.address(), "admin", "secret").Get("/hosts?name__in=host-2", 5); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entries, err = tflogtest.MultilineJSONDecode(&providerOutput)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	found := false
+	for _, entry := range entries {
+		if entry["@message"] == "Silk API request" && entry["sdp_object_name"] == "host-2" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected the request to be logged with the logger of the provider")
+	}
+}
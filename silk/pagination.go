@@ -0,0 +1,117 @@
+package silk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// listPage holds a page of the response of the Silk server to a list request. The API returns at most limit objects
+// per request, starting at offset, along with the total number of objects that match the request.
+type listPage struct {
+	Hits   []json.RawMessage `json:"hits"`
+	Limit  *int              `json:"limit"`
+	Offset *int              `json:"offset"`
+	Total  *int              `json:"total"`
+}
+
+// fetchAll sends the GET request to the Silk server and, when the response is a page of a list, requests the
+// remaining pages with the __offset and __limit query parameters. The SDK only reads the first page, so the hits of
+// every page are merged into a single response that contains all the objects.
+//
+// Objects may be created or deleted while the pages are requested, which shifts the objects between pages, so an
+// object returned on two pages is only kept once.
+func (g *gateway) fetchAll(ctx context.Context, uri string, header http.Header) (*gatewayResponse, error) {
+
+	response, err := g.do(ctx, http.MethodGet, uri, header, nil)
+	if err != nil || response.StatusCode != http.StatusOK {
+		return response, err
+	}
+
+	requestURL, err := url.Parse(uri)
+	if err != nil {
+		return response, nil
+	}
+	query := requestURL.Query()
+
+	// The caller asked for a specific page
+	if query.Get("__offset") != "" || query.Get("__limit") != "" {
+		return response, nil
+	}
+
+	var body map[string]json.RawMessage
+	var page listPage
+	if json.Unmarshal(response.Body, &body) != nil || json.Unmarshal(response.Body, &page) != nil {
+		return response, nil
+	}
+	if page.Total == nil || page.Limit == nil || *page.Limit <= 0 || len(page.Hits) >= *page.Total {
+		return response, nil
+	}
+
+	hits := page.Hits
+	seen := map[string]bool{}
+	for _, hit := range hits {
+		seen[hitID(hit)] = true
+	}
+
+	for offset := len(page.Hits); offset < *page.Total; {
+		query.Set("__offset", strconv.Itoa(offset))
+		query.Set("__limit", strconv.Itoa(*page.Limit))
+		requestURL.RawQuery = query.Encode()
+
+		pageResponse, err := g.do(ctx, http.MethodGet, requestURL.String(), header, nil)
+		if err != nil || pageResponse.StatusCode != http.StatusOK {
+			return pageResponse, err
+		}
+
+		var next listPage
+		if err := json.Unmarshal(pageResponse.Body, &next); err != nil {
+			return nil, fmt.Errorf("Unable to read the page at offset %d of %s: %s", offset, uri, err)
+		}
+
+		// The list shrank while it was paged through
+		if len(next.Hits) == 0 {
+			break
+		}
+
+		for _, hit := range next.Hits {
+			if id := hitID(hit); id == "" || !seen[id] {
+				seen[id] = true
+				hits = append(hits, hit)
+			}
+		}
+
+		offset += len(next.Hits)
+		if next.Total != nil {
+			page.Total = next.Total
+		}
+	}
+
+	total := len(hits)
+	body["hits"], _ = json.Marshal(hits)
+	body["total"], _ = json.Marshal(total)
+	body["limit"], _ = json.Marshal(total)
+	body["offset"], _ = json.Marshal(0)
+
+	merged, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gatewayResponse{StatusCode: response.StatusCode, Header: response.Header, Body: merged}, nil
+}
+
+// hitID returns the id of an object in the hits of a list, or an empty string when it does not have one
+func hitID(hit json.RawMessage) string {
+	var object struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if json.Unmarshal(hit, &object) != nil {
+		return ""
+	}
+
+	return string(object.ID)
+}
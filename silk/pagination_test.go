@@ -0,0 +1,163 @@
+package silk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// testPageSize is the number of objects the test Silk server returns per page when the request does not set __limit
+const testPageSize = 1000
+
+// testInventory returns a handler that serves the provided number of volumes, hosts, and host groups one page at a
+// time like the Silk server. The onPage function, when set, is called before each page is returned.
+func testInventory(t *testing.T, count int, requests *int32, onPage func(offset int) int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		query := r.URL.Query()
+		offset, _ := strconv.Atoi(query.Get("__offset"))
+		limit := testPageSize
+		if query.Get("__limit") != "" {
+			limit, _ = strconv.Atoi(query.Get("__limit"))
+		}
+
+		// The first id of the inventory, which changes when objects are added to the start of the list
+		first := 1
+		if onPage != nil {
+			first = onPage(offset)
+		}
+
+		hits := []map[string]interface{}{}
+		for id := first + offset; id < first+count && len(hits) < limit; id++ {
+			hits = append(hits, map[string]interface{}{"id": id, "name": fmt.Sprintf("object-%d", id)})
+		}
+
+		body, err := json.Marshal(map[string]interface{}{"hits": hits, "limit": limit, "offset": offset, "total": count})
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		w.Write(body)
+	}
+}
+
+func TestGatewayPagination(t *testing.T) {
+
+	testCases := []struct {
+		name             string
+		count            int
+		uri              string
+		expectedHits     int
+		expectedRequests int32
+	}{
+		{"empty", 0, "/api/v2/volumes", 0, 1},
+		{"single page", 999, "/api/v2/volumes", 999, 1},
+		{"full page", 1000, "/api/v2/volumes", 1000, 1},
+		{"one more than a page", 1001, "/api/v2/volumes", 1001, 2},
+		{"several pages", 2500, "/api/v2/hosts", 2500, 3},
+		{"filtered query", 2500, "/api/v2/host_groups?name__contains=object", 2500, 3},
+		{"explicit page", 2500, "/api/v2/volumes?__limit=10", 10, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests int32
+			g := testGateway(t, testInventory(t, tc.count, &requests, nil))
+
+			response, err := g.forward(context.Background(), "GET", tc.uri, http.Header{}, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var page struct {
+				Hits  []struct{ ID int } `json:"hits"`
+				Total int                `json:"total"`
+			}
+			if err := json.Unmarshal(response.Body, &page); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(page.Hits) != tc.expectedHits {
+				t.Errorf("expected %d hits, got %d", tc.expectedHits, len(page.Hits))
+			}
+
+			for i, hit := range page.Hits {
+				if hit.ID != i+1 {
+					t.Fatalf("expected the hit %d to have the id %d, got %d", i, i+1, hit.ID)
+				}
+			}
+
+			if page.Total != tc.count {
+				t.Errorf("expected a total of %d, got %d", tc.count, page.Total)
+			}
+
+			if requests != tc.expectedRequests {
+				t.Errorf("expected %d requests, got %d", tc.expectedRequests, requests)
+			}
+		})
+	}
+}
+
+// TestGatewayPaginationShift validates an object is returned once when an object is created while the pages are
+// requested, which shifts every object of the list to the next page.
+func TestGatewayPaginationShift(t *testing.T) {
+
+	var requests int32
+	g := testGateway(t, testInventory(t, 2500, &requests, func(offset int) int {
+		if offset == 0 {
+			return 1
+		}
+		return 0
+	}))
+
+	response, err := g.forward(context.Background(), "GET", "/api/v2/volumes", http.Header{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var page struct {
+		Hits []struct{ ID int } `json:"hits"`
+	}
+	if err := json.Unmarshal(response.Body, &page); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	seen := map[int]bool{}
+	for _, hit := range page.Hits {
+		if seen[hit.ID] {
+			t.Fatalf("the id %d was returned more than once", hit.ID)
+		}
+		seen[hit.ID] = true
+	}
+}
+
+// TestGatewayPaginationSDK validates the lookups of the SDK find the objects that are not on the first page
+func TestGatewayPaginationSDK(t *testing.T) {
+
+	var requests int32
+	g := testGateway(t, testInventory(t, 1500, &requests, nil))
+
+	silk := silksdp.Connect(g.address(), "admin", "secret")
+
+	volumes, err := silk.GetVolumes(5)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(volumes.Hits) != 1500 {
+		t.Errorf("expected 1500 volumes, got %d", len(volumes.Hits))
+	}
+
+	id, err := silk.GetHostID("object-1500", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if id != 1500 {
+		t.Errorf("expected the id 1500, got %d", id)
+	}
+}
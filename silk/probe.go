@@ -0,0 +1,109 @@
+package silk
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/mitchellh/mapstructure"
+)
+
+// probe makes an authenticated call to the Silk server when the provider is configured so a wrong server, TLS
+// setting, or password is reported once, with the reason of the failure, instead of by every resource. The state of
+// the system, which includes its software version, is returned.
+func (g *gateway) probe(ctx context.Context, c *Config) (*systemState, diag.Diagnostics) {
+
+	header := http.Header{}
+	header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.Username+":"+c.Password)))
+
+	uri := "/api/v2/system/state"
+	response, err := g.do(g.logContext(ctx, &operation{ctx: ctx}, uri, nil), "GET", uri, header, nil)
+	if diags := probeDiagnostics(c.Server, c.Username, response, err); diags.HasError() {
+		return nil, diags
+	}
+
+	var apiResponse struct {
+		Hits []systemState `mapstructure:"hits"`
+	}
+	var body interface{}
+	if json.Unmarshal(response.Body, &body) == nil {
+		mapstructure.Decode(body, &apiResponse)
+	}
+	if len(apiResponse.Hits) == 0 {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unexpected response from the Silk server '%s'", c.Server),
+			Detail:   "The server did not return the system state. Verify the server argument points to the management interface of a Silk server.",
+		}}
+	}
+
+	return &apiResponse.Hits[0], nil
+}
+
+// probeDiagnostics returns a single diagnostic that explains why the probe of the Silk server failed. The failures
+// are reported, in the order a connection is made, as a DNS, TCP, TLS, authentication, or authorization failure.
+func probeDiagnostics(server, username string, response *gatewayResponse, err error) diag.Diagnostics {
+
+	failed := func(summary, detail string) diag.Diagnostics {
+		return diag.Diagnostics{{Severity: diag.Error, Summary: summary, Detail: detail}}
+	}
+
+	if err != nil {
+		var dnsErr *net.DNSError
+		var opErr *net.OpError
+		var recordErr tls.RecordHeaderError
+
+		switch {
+		case errors.As(err, &dnsErr):
+			return failed(
+				fmt.Sprintf("DNS failure: unable to resolve the Silk server '%s'", server),
+				fmt.Sprintf("%s. Verify the server argument, or the SILK_SDP_SERVER environment variable, and the DNS settings of the machine running Terraform.", err),
+			)
+		case certificateError(err) || errors.As(err, &recordErr) || strings.Contains(err.Error(), "tls:"):
+			return failed(
+				fmt.Sprintf("TLS failure: unable to establish a secure connection to the Silk server '%s'", server),
+				fmt.Sprintf("%s. Set ca_bundle to the CA that signed the certificate of the server, tls_server_name to a name in the certificate, or insecure to true to skip the verification.", err),
+			)
+		case errors.As(err, &opErr) || isTimeout(err):
+			return failed(
+				fmt.Sprintf("TCP failure: unable to connect to the Silk server '%s'", server),
+				fmt.Sprintf("%s. Verify the server is reachable from the machine running Terraform on port 443 and that request_timeout is long enough.", err),
+			)
+		default:
+			return failed(fmt.Sprintf("Unable to connect to the Silk server '%s'", server), err.Error())
+		}
+	}
+
+	switch {
+	case response.StatusCode == http.StatusUnauthorized:
+		return failed(
+			fmt.Sprintf("Authentication failure: the Silk server '%s' rejected the credentials of the user '%s'", server, username),
+			"Verify the username and password arguments, or the SILK_SDP_USERNAME and SILK_SDP_PASSWORD environment variables.",
+		)
+	case response.StatusCode == http.StatusForbidden:
+		return failed(
+			fmt.Sprintf("Authorization failure: the user '%s' is not allowed to use the API of the Silk server '%s'", username, server),
+			"The credentials are valid, but the user does not have the permissions required by the provider.",
+		)
+	case response.StatusCode < 200 || response.StatusCode > 299:
+		return failed(
+			fmt.Sprintf("Unexpected response from the Silk server '%s'", server),
+			fmt.Sprintf("The server returned %d %s. Verify the server argument points to the management interface of a Silk server.", response.StatusCode, http.StatusText(response.StatusCode)),
+		)
+	}
+
+	return nil
+}
+
+// isTimeout returns true when the error is a network timeout
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
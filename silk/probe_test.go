@@ -0,0 +1,82 @@
+package silk
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProbe(t *testing.T) {
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		switch {
+		case password != "secret":
+			w.WriteHeader(http.StatusUnauthorized)
+		case username == "viewer":
+			w.WriteHeader(http.StatusForbidden)
+		case username == "proxy":
+			w.Write([]byte(`<html></html>`))
+		default:
+			w.Write([]byte(`{"hits": [{"system_version": "6.0.0", "state": "online"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	// An address nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	closedAddress := listener.Addr().String()
+	listener.Close()
+
+	testCases := []struct {
+		name            string
+		server          string
+		config          Config
+		expectedSummary string
+	}{
+		{"success", server.Listener.Addr().String(), Config{Username: "admin", Password: "secret", Insecure: true}, ""},
+		{"dns", "silk-sdp.invalid", Config{Username: "admin", Password: "secret"}, "DNS failure"},
+		{"tcp", closedAddress, Config{Username: "admin", Password: "secret"}, "TCP failure"},
+		{"tls", server.Listener.Addr().String(), Config{Username: "admin", Password: "secret"}, "TLS failure"},
+		{"authentication", server.Listener.Addr().String(), Config{Username: "admin", Password: "wrong", Insecure: true}, "Authentication failure"},
+		{"authorization", server.Listener.Addr().String(), Config{Username: "viewer", Password: "secret", Insecure: true}, "Authorization failure"},
+		{"not a Silk server", server.Listener.Addr().String(), Config{Username: "proxy", Password: "secret", Insecure: true}, "Unexpected response"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config.Server = tc.server
+			tc.config.RequestTimeout = 5
+
+			g, err := newGateway(&tc.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			state, diags := g.probe(context.Background(), &tc.config)
+
+			if tc.expectedSummary == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %s", diags[0].Summary)
+				}
+				if state.SystemVersion != "6.0.0" {
+					t.Errorf("expected the system_version 6.0.0, got %s", state.SystemVersion)
+				}
+				return
+			}
+
+			if len(diags) != 1 {
+				t.Fatalf("expected a single diagnostic, got %d", len(diags))
+			}
+			if !strings.HasPrefix(diags[0].Summary, tc.expectedSummary) {
+				t.Errorf("expected a summary starting with %q, got %q", tc.expectedSummary, diags[0].Summary)
+			}
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package silk
 
 import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider -
@@ -26,30 +30,117 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("SILK_SDP_PASSWORD", nil),
 				Description: "The password used to authenticate against the Silk Sever.",
 			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      15,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of seconds to wait for each request to the Silk server before it is considered failed.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The number of times a request that fails with a transient error (connection failure, 5xx, 409, or 429) is retried.",
+			},
+			"retry_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of seconds to wait before the first retry of a request. The delay doubles with each retry, up to 30 seconds, with a random jitter applied.",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of requests sent to the Silk server at the same time.",
+			},
+			"cache_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SILK_SDP_CACHE_ENABLED", true),
+				Description: "Cache the objects read from the Silk server for the duration of a Terraform run. The cache is cleared each time an object is created, updated, or deleted.",
+			},
+			"ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SILK_SDP_CA_BUNDLE", nil),
+				Description: "The path to, or the PEM encoded content of, the CA certificates used to verify the certificate of the Silk server. When not set, the CA certificates of the system are used.",
+			},
+			"client_certificate": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key"},
+				Description:  "The path to, or the PEM encoded content of, the certificate presented to the Silk server.",
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"client_certificate"},
+				Description:  "The path to, or the PEM encoded content of, the private key of client_certificate.",
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name used to verify the certificate of the Silk server instead of the server argument. Use it when the server is set to an IP address that is not in the certificate.",
+			},
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SILK_SDP_INSECURE", false),
+				Description: "When set to true, the certificate of the Silk server is not verified.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"silk_volume":           resourceSilkVolume(),
-			"silk_volume_group":     resourceSilkVolumeGroup(),
-			"silk_host":             resourceSilkHost(),
-			"silk_host_group":       resourceSilkHostGroup(),
-			"silk_retention_policy": resourceSilkRetentionPolicy(),
-			"silk_capacity_policy":  resourceSilkCapacityPolicy(),
+			"silk_volume":                    resourceSilkVolume(),
+			"silk_volume_group":              resourceSilkVolumeGroup(),
+			"silk_host":                      resourceSilkHost(),
+			"silk_host_group":                resourceSilkHostGroup(),
+			"silk_retention_policy":          resourceSilkRetentionPolicy(),
+			"silk_capacity_policy":           resourceSilkCapacityPolicy(),
+			"silk_snapshot":                  resourceSilkSnapshot(),
+			"silk_volume_view":               resourceSilkVolumeView(),
+			"silk_host_volume_mapping":       resourceSilkHostVolumeMapping(),
+			"silk_host_group_volume_mapping": resourceSilkHostGroupVolumeMapping(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"silk_volume":       dataSourceSilkVolume(),
+			"silk_volumes":      dataSourceSilkVolumes(),
+			"silk_host":         dataSourceSilkHost(),
+			"silk_hosts":        dataSourceSilkHosts(),
+			"silk_volume_group": dataSourceSilkVolumeGroup(),
+			"silk_host_group":   dataSourceSilkHostGroup(),
+			"silk_system":       dataSourceSilkSystem(),
+			"silk_target_ports": dataSourceSilkTargetPorts(),
 		},
-		DataSourcesMap: map[string]*schema.Resource{},
 
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure,
 	}
 }
 
 // providerConfigure creates an interface{} that is stored and passed into subsequent resources as the
 // meta parameter. This return value is used to pass along the configured Silk Go SDK API client
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	config := Config{
-		Server:   d.Get("server").(string),
-		Username: d.Get("username").(string),
-		Password: d.Get("password").(string),
+		Server:                d.Get("server").(string),
+		Username:              d.Get("username").(string),
+		Password:              d.Get("password").(string),
+		RequestTimeout:        d.Get("request_timeout").(int),
+		MaxRetries:            d.Get("max_retries").(int),
+		RetryBackoff:          d.Get("retry_backoff").(int),
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		CacheEnabled:          d.Get("cache_enabled").(bool),
+		CABundle:              d.Get("ca_bundle").(string),
+		ClientCertificate:     d.Get("client_certificate").(string),
+		ClientKey:             d.Get("client_key").(string),
+		TLSServerName:         d.Get("tls_server_name").(string),
+		Insecure:              d.Get("insecure").(bool),
 	}
 
-	return config.Client()
+	return config.Client(ctx)
 }
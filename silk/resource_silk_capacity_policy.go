@@ -2,17 +2,14 @@ package silk
 
 import (
 	"context"
-	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
 )
 
 func resourceSilkCapacityPolicy() *schema.Resource {
-	return &schema.Resource{
+	return withIDStateUpgrader(&schema.Resource{
 		CreateContext: resourceSilkCapacityPolicyCreate,
 		ReadContext:   resourceSilkCapacityPolicyRead,
 		UpdateContext: resourceSilkCapacityPolicyUpdate,
@@ -20,6 +17,13 @@ func resourceSilkCapacityPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSilkCapacityPolicyImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultOperationTimeout),
+			Read:   schema.DefaultTimeout(defaultOperationTimeout),
+			Update: schema.DefaultTimeout(defaultOperationTimeout),
+			Delete: schema.DefaultTimeout(defaultOperationTimeout),
+		},
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -64,14 +68,21 @@ func resourceSilkCapacityPolicy() *schema.Resource {
 				ForceNew:    true,
 				Description: "Percentage of capacity used by snapshots to generate an alert.",
 			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a Capacity Policy with the same name that already exists on the Silk server is taken over instead of being created. Its attributes are updated to match the configuration.",
+			},
 			"timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     15,
+				Deprecated:  "Use the timeouts block and the request_timeout argument of the provider instead. This value is no longer used.",
 				Description: "The number of seconds to wait to establish a connection the Silk server before returning a timeout error.",
 			},
 		},
-	}
+	})
 
 }
 
@@ -79,14 +90,19 @@ func resourceSilkCapacityPolicyCreate(ctx context.Context, d *schema.ResourceDat
 
 	// Read in the resource schema arguments for easier assignment
 	name := d.Get("name").(string)
+
+	if adopted, diags := adoptExisting(ctx, resourceSilkCapacityPolicy(), d, m, "Capacity Policy", name); adopted {
+		return diags
+	}
+
 	warningthreshold := d.Get("warningthreshold").(int)
 	errorthreshold := d.Get("errorthreshold").(int)
 	criticalthreshold := d.Get("criticalthreshold").(int)
 	fullthreshold := 100
 	snapshotoverheadthreshold := d.Get("snapshotoverheadthreshold").(int)
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
 	CapacityPolicy, err := silk.CreateCapacityPolicy(name, warningthreshold, errorthreshold, criticalthreshold, fullthreshold, snapshotoverheadthreshold, timeout)
 	if err != nil {
@@ -94,7 +110,7 @@ func resourceSilkCapacityPolicyCreate(ctx context.Context, d *schema.ResourceDat
 	}
 
 	// Set the resource ID
-	d.SetId(fmt.Sprintf("silk-CapacityPolicy-%d-%s", CapacityPolicy.ID, strconv.FormatInt(time.Now().Unix(), 10)))
+	d.SetId(strconv.Itoa(CapacityPolicy.ID))
 
 	return resourceSilkCapacityPolicyRead(ctx, d, m)
 }
@@ -105,9 +121,9 @@ func resourceSilkCapacityPolicyRead(ctx context.Context, d *schema.ResourceData,
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
 	getCapacityPolicy, err := silk.GetCapacityPolicy(timeout)
 	if err != nil {
@@ -115,7 +131,7 @@ func resourceSilkCapacityPolicyRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	for _, CapacityPolicy := range getCapacityPolicy.Hits {
-		if CapacityPolicy.Name == d.Get("name").(string) {
+		if strconv.Itoa(CapacityPolicy.ID) == d.Id() {
 
 			d.Set("name", CapacityPolicy.Name)
 			d.Set("obj_id", CapacityPolicy.ID)
@@ -139,9 +155,9 @@ func resourceSilkCapacityPolicyRead(ctx context.Context, d *schema.ResourceData,
 func resourceSilkCapacityPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	/* This endpoint does not provide a PATCH method, so this was written in waste.
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
 	config := map[string]interface{}{}
 	var CapacityPolicyName string
@@ -191,10 +207,11 @@ func resourceSilkCapacityPolicyDelete(ctx context.Context, d *schema.ResourceDat
 	var diags diag.Diagnostics
 
 	name := d.Get("name").(string)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
-	_, err := silk.DeleteCapacityPolicy(name)
+	_, err := silk.DeleteCapacityPolicy(name, timeout)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -205,31 +222,31 @@ func resourceSilkCapacityPolicyDelete(ctx context.Context, d *schema.ResourceDat
 }
 func resourceSilkCapacityPolicyImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 
-	timeout := d.Get("timeout").(int)
+	d.Set("timeout", 15)
+	d.Set("adopt_existing", false)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
 	getCapacityPolicy, err := silk.GetCapacityPolicy(timeout)
 	if err != nil {
 		return nil, err
 	}
 
+	// Capacity Policies can be imported by their SDP ID or by their name
 	for _, CapacityPolicy := range getCapacityPolicy.Hits {
-		if CapacityPolicy.Name == d.Id() {
+		if importMatches(d.Id(), CapacityPolicy.ID, CapacityPolicy.Name) {
 
-			d.Set("name", CapacityPolicy.Name)
-			d.Set("obj_id", CapacityPolicy.ID)
-			d.Set("warningthreshold", CapacityPolicy.WarningThreshold)
-			d.Set("errorthreshold", CapacityPolicy.ErrorThreshold)
-			d.Set("criticalthreshold", CapacityPolicy.CriticalThreshold)
-			d.Set("fullthreshold", CapacityPolicy.FullThreshold)
-			d.Set("snapshotoverheadthreshold", CapacityPolicy.SnapshotOverheadThreshold)
-			d.Set("timeout", 15)
-			d.SetId(fmt.Sprintf("silk-CapacityPolicy-%d-%s", CapacityPolicy.ID, strconv.FormatInt(time.Now().Unix(), 10)))
+			d.SetId(strconv.Itoa(CapacityPolicy.ID))
 
+			diags := resourceSilkCapacityPolicyRead(ctx, d, m)
+			if diags.HasError() {
+				return nil, diagsError(diags)
+			}
+
+			return []*schema.ResourceData{d}, nil
 		}
 	}
 
-	return []*schema.ResourceData{d}, nil
-
+	return nil, notFoundError("The server does not contain a Capacity Policy with the ID or name '%s'", d.Id())
 }
@@ -2,19 +2,17 @@ package silk
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 	"sort"
 	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSilkHost() *schema.Resource {
-	return &schema.Resource{
+	return withIDStateUpgrader(&schema.Resource{
 		CreateContext: resourceSilkHostCreate,
 		ReadContext:   resourceSilkHostRead,
 		UpdateContext: resourceSilkHostUpdate,
@@ -22,6 +20,13 @@ func resourceSilkHost() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSilkHostImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultOperationTimeout),
+			Read:   schema.DefaultTimeout(defaultOperationTimeout),
+			Update: schema.DefaultTimeout(defaultOperationTimeout),
+			Delete: schema.DefaultTimeout(defaultOperationTimeout),
+		},
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -55,43 +60,96 @@ func resourceSilkHost() *schema.Resource {
 				Default:     "",
 				Description: "The IQN that is mapped to the Host.",
 			},
+			"mapping_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{mappingModeAuthoritative, mappingModeAdditive}, false),
+				Description:  "Either `authoritative` or `additive`. In authoritative mode, PWWNs added to the Host outside of Terraform are shown as drift and removed. In additive mode, they are ignored. When not set, the PWWNs are managed in authoritative mode once at least one is configured, and ignored otherwise.",
+			},
+			"rollback_on_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a Host whose PWWNs or IQN fail to be added is removed instead of being kept in the state as tainted.",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a Host with the same name that already exists on the Silk server is taken over instead of being created. Its attributes are updated to match the configuration.",
+			},
 			"timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     15,
+				Deprecated:  "Use the timeouts block and the request_timeout argument of the provider instead. This value is no longer used.",
 				Description: "The number of seconds to wait to establish a connection the Silk server before returning a timeout error.",
 			},
 		},
-	}
+	})
 }
 
 func resourceSilkHostCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	// Read in the resource schema arguments for easier assignment
 	name := d.Get("name").(string)
+
+	if adopted, diags := adoptExisting(ctx, resourceSilkHost(), d, m, "Host", name); adopted {
+		return diags
+	}
+
 	hostType := d.Get("host_type").(string)
 	pwwn := d.Get("pwwn").([]interface{})
 	iqn := d.Get("iqn").(string)
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
+
+	unlock := m.(*Client).lock(hostLockKey(name))
+	defer unlock()
 
 	host, err := silk.CreateHost(name, hostType, timeout)
 	if err != nil {
 		return diag.FromErr(err)
 	} else {
-		// Set the resource ID
-		d.SetId(fmt.Sprintf("silk-host-%d-%s", host.ID, strconv.FormatInt(time.Now().Unix(), 10))) // <-- maybe simply make this the the name or host.ID?
+		// Save the ID immediately so the Host is tracked even when one of the steps below fails
+		d.SetId(strconv.Itoa(host.ID))
 		d.Set("obj_id", host.ID)
 	}
 
+	// Only record the PWWNs and IQN that have been created when one of the steps fails
+	createdPwwns := []interface{}{}
+	createdIqn := ""
+	d.Set("pwwn", createdPwwns)
+	d.Set("iqn", "")
+	rollback := func() error {
+		// Undo the steps in the reverse order they were made
+		if createdIqn != "" {
+			_, err := silk.DeleteHostIndividualIQN(name, createdIqn, timeout)
+			if err != nil {
+				return err
+			}
+		}
+
+		for i := len(createdPwwns) - 1; i >= 0; i-- {
+			_, err := silk.DeleteHostIndividualPWWN(name, createdPwwns[i].(string), timeout)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := silk.DeleteHost(name, timeout)
+		return err
+	}
+
 	if len(pwwn) != 0 {
 		for _, p := range pwwn {
 			_, err := silk.CreateHostPWWN(name, p.(interface{}).(string), timeout)
 			if err != nil {
-				return diag.FromErr(err)
+				return createFailed(d, "Host", rollback, err)
 			} else {
-				d.Set("pwwn", pwwn)
+				createdPwwns = append(createdPwwns, p)
+				d.Set("pwwn", createdPwwns)
 			}
 		}
 	}
@@ -99,8 +157,9 @@ func resourceSilkHostCreate(ctx context.Context, d *schema.ResourceData, m inter
 	if iqn != "" {
 		_, err := silk.CreateHostIQN(name, iqn, timeout)
 		if err != nil {
-			return diag.FromErr(err)
+			return createFailed(d, "Host", rollback, err)
 		} else {
+			createdIqn = iqn
 			d.Set("iqn", iqn)
 		}
 	}
@@ -113,9 +172,9 @@ func resourceSilkHostRead(ctx context.Context, d *schema.ResourceData, m interfa
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
 	// name := d.Get("name").(string)
 
@@ -125,25 +184,23 @@ func resourceSilkHostRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
-	// Gimme an id as an int <-- this breaks creation...
-	// hostID, err := strconv.Atoi(d.Id())
-	// if err != nil {
-	// 	return diag.FromErr(err)
-	// }
-
 	for _, host := range getHost.Hits {
-		if (d.Get("obj_id") != nil && host.ID == d.Get("obj_id").(int)) || (d.Get("obj_id") == nil && host.Name == d.Get("name").(string)) {
+		if strconv.Itoa(host.ID) == d.Id() {
 
 			d.Set("name", host.Name)
 			d.Set("host_type", host.Type)
 			d.Set("obj_id", host.ID)
 
-			if len(d.Get("pwwn").([]interface{})) != 0 {
+			pwwn := d.Get("pwwn").([]interface{})
+			mappingMode := listMappingMode(d.Get("mapping_mode").(string), pwwn)
+
+			// In additive mode there is nothing to refresh until Terraform manages at least one PWWN
+			if mappingMode == mappingModeAuthoritative || len(pwwn) != 0 {
 
 				// Get the current PWWNs on the host and then set the TF pwwn value with
-				// those responses
+				// the PWWNs managed by Terraform
 				pwwns := []string{}
-				getPwwn, err := silk.GetHostPWWN(d.Get("name").(string))
+				getPwwn, err := silk.GetHostPWWN(d.Get("name").(string), timeout)
 				if err != nil {
 					return diag.FromErr(err)
 				}
@@ -151,6 +208,8 @@ func resourceSilkHostRead(ctx context.Context, d *schema.ResourceData, m interfa
 					pwwns = append(pwwns, value.Pwwn)
 				}
 
+				pwwns = managedNames(mappingMode, pwwn, pwwns)
+
 				// Sort the new slice to prevent any TF comparison issues
 				sort.Slice(pwwns, func(i, j int) bool {
 					return pwwns[i] < pwwns[j]
@@ -165,7 +224,7 @@ func resourceSilkHostRead(ctx context.Context, d *schema.ResourceData, m interfa
 				// Get the current IQNs on the host and then set the TF IQN value with
 				// those responses
 				iqns := []string{}
-				getIQN, err := silk.GetHostIQN(d.Get("name").(string))
+				getIQN, err := silk.GetHostIQN(d.Get("name").(string), timeout)
 				if err != nil {
 					return diag.FromErr(err)
 				}
@@ -195,9 +254,9 @@ func resourceSilkHostRead(ctx context.Context, d *schema.ResourceData, m interfa
 
 func resourceSilkHostUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
 	config := map[string]interface{}{}
 	var currentHostName string
@@ -211,6 +270,9 @@ func resourceSilkHostUpdate(ctx context.Context, d *schema.ResourceData, m inter
 		currentHostName = d.Get("name").(string)
 	}
 
+	unlock := m.(*Client).lock(hostLockKey(currentHostName), hostLockKey(d.Get("name").(string)))
+	defer unlock()
+
 	pwwnToRemove := []string{}
 	pwwnToAdd := []string{}
 	if d.HasChange("pwwn") {
@@ -241,13 +303,13 @@ func resourceSilkHostUpdate(ctx context.Context, d *schema.ResourceData, m inter
 			if foundInNew && !foundInCurrent {
 				pwwnToAdd = append(pwwnToAdd, h)
 			} else if !foundInNew && foundInCurrent {
-				pwwnToAdd = append(pwwnToRemove, h)
+				pwwnToRemove = append(pwwnToRemove, h)
 			}
 		}
 
 		// Add each PWWN to the Host
 		for _, p := range pwwnToAdd {
-			_, err := silk.CreateHostPWWN(currentHostName, p)
+			_, err := silk.CreateHostPWWN(currentHostName, p, timeout)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -255,7 +317,7 @@ func resourceSilkHostUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 		// Remove each PWWN from the Host
 		for _, p := range pwwnToRemove {
-			_, err := silk.DeleteHostIndividualPWWN(currentHostName, p)
+			_, err := silk.DeleteHostIndividualPWWN(currentHostName, p, timeout)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -304,9 +366,9 @@ func resourceSilkHostDelete(ctx context.Context, d *schema.ResourceData, m inter
 	var diags diag.Diagnostics
 
 	name := d.Get("name").(string)
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
 	// host, err := silk.GetHost(name,timeout)
 	// if err != nil {
@@ -334,6 +396,9 @@ func resourceSilkHostDelete(ctx context.Context, d *schema.ResourceData, m inter
 	// 	return diag.FromErr(err)
 	// }
 
+	unlock := m.(*Client).lock(hostLockKey(name))
+	defer unlock()
+
 	_, err := silk.DeleteHost(name, timeout)
 	if err != nil {
 		return diag.FromErr(err)
@@ -346,28 +411,24 @@ func resourceSilkHostDelete(ctx context.Context, d *schema.ResourceData, m inter
 
 func resourceSilkHostImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 
-	timeout := d.Get("timeout").(int)
+	d.Set("timeout", 15)
+	d.Set("adopt_existing", false)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
-	name := d.Get("name").(string)
-
-	getHost, err := silk.GetHostByName(name, timeout)
+	getHost, err := silk.GetHosts(timeout)
 	if err != nil {
 		return nil, err
 	}
 
+	// Hosts can be imported by their SDP ID or by their name
 	for _, host := range getHost.Hits {
-		if host.Name == d.Id() {
-			// Set the base data
-			d.Set("name", host.Name)
-			d.Set("obj_id", host.ID)
-			d.Set("host_type", host.Type)
-			d.Set("timeout", 15)
+		if importMatches(d.Id(), host.ID, host.Name) {
 
 			// Check for IQNs
 			iqns := []string{}
-			getIQN, err := silk.GetHostIQN(d.Get("name").(string))
+			getIQN, err := silk.GetHostIQN(host.Name, timeout)
 			if err != nil {
 				return nil, err
 			}
@@ -384,7 +445,7 @@ func resourceSilkHostImport(ctx context.Context, d *schema.ResourceData, m inter
 
 			// Check for pwwns
 			pwwns := []string{}
-			getPwwn, err := silk.GetHostPWWN(d.Get("name").(string))
+			getPwwn, err := silk.GetHostPWWN(host.Name, timeout)
 			if err != nil {
 				return nil, err
 			}
@@ -393,16 +454,20 @@ func resourceSilkHostImport(ctx context.Context, d *schema.ResourceData, m inter
 			}
 
 			// Sort the new slice to prevent any TF comparison issues
-			sort.Slice(pwwns, func(i, j int) bool {
-				return pwwns[i] < pwwns[j]
-			})
+			sort.Strings(pwwns)
 
 			d.Set("pwwn", pwwns)
+			d.Set("rollback_on_failure", false)
+			d.SetId(strconv.Itoa(host.ID))
+
+			diags := resourceSilkHostRead(ctx, d, m)
+			if diags.HasError() {
+				return nil, diagsError(diags)
+			}
 
-			// Set the ID
-			d.SetId(fmt.Sprintf("silk-host-%d-%s", host.ID, strconv.FormatInt(time.Now().Unix(), 10)))
+			return []*schema.ResourceData{d}, nil
 		}
 	}
 
-	return []*schema.ResourceData{d}, nil
+	return nil, notFoundError("The server does not contain a Host with the ID or name '%s'", d.Id())
 }
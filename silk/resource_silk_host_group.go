@@ -2,19 +2,17 @@ package silk
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 	"sort"
 	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceSilkHostGroup() *schema.Resource {
-	return &schema.Resource{
+	return withIDStateUpgrader(&schema.Resource{
 		CreateContext: resourceSilkHostGroupCreate,
 		ReadContext:   resourceSilkHostGroupRead,
 		UpdateContext: resourceSilkHostGroupUpdate,
@@ -22,6 +20,13 @@ func resourceSilkHostGroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSilkHostGroupImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultOperationTimeout),
+			Read:   schema.DefaultTimeout(defaultOperationTimeout),
+			Update: schema.DefaultTimeout(defaultOperationTimeout),
+			Delete: schema.DefaultTimeout(defaultOperationTimeout),
+		},
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -55,14 +60,33 @@ func resourceSilkHostGroup() *schema.Resource {
 				},
 				Description: "An optional list of Hosts that belong to the Host Group.",
 			},
+			"mapping_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{mappingModeAuthoritative, mappingModeAdditive}, false),
+				Description:  "Either `authoritative` or `additive`. In authoritative mode, Hosts added to the Host Group outside of Terraform are shown as drift and removed. In additive mode, they are ignored. When not set, the Hosts of the Host Group are managed in authoritative mode once at least one is configured, and ignored otherwise.",
+			},
+			"rollback_on_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a Host Group whose Hosts fail to be added is removed instead of being kept in the state as tainted.",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a Host Group with the same name that already exists on the Silk server is taken over instead of being created. Its attributes are updated to match the configuration.",
+			},
 			"timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     15,
+				Deprecated:  "Use the timeouts block and the request_timeout argument of the provider instead. This value is no longer used.",
 				Description: "The number of seconds to wait to establish a connection the Silk server before returning a timeout error.",
 			},
 		},
-	}
+	})
 
 }
 
@@ -70,30 +94,57 @@ func resourceSilkHostGroupCreate(ctx context.Context, d *schema.ResourceData, m
 
 	// Read in the resource schema arguments for easier assignment
 	name := d.Get("name").(string)
+
+	if adopted, diags := adoptExisting(ctx, resourceSilkHostGroup(), d, m, "Host Group", name); adopted {
+		return diags
+	}
+
 	description := d.Get("description").(string)
 	allowDifferentHostTypes := d.Get("allow_different_host_types").(bool)
 	hostMapping := d.Get("host_mapping").([]interface{})
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
 
-	silk := m.(*silksdp.Credentials)
+	// Adding a Host to the Host Group changes the Host as well
+	unlock := m.(*Client).lock(append(lockKeys(d, "host_mapping", hostLockKey), hostGroupLockKey(name))...)
+	defer unlock()
 
 	hostGroup, err := silk.CreateHostGroup(name, description, allowDifferentHostTypes, timeout)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	// Save the ID immediately so the Host Group is tracked even when one of the steps below fails
+	d.SetId(strconv.Itoa(hostGroup.ID))
+
+	// Only record the Hosts that have been added when one of the steps fails
+	addedHosts := []interface{}{}
+	d.Set("host_mapping", addedHosts)
+	rollback := func() error {
+		for _, h := range addedHosts {
+			_, err := silk.DeleteHostHostGroupMapping(h.(string), name, timeout)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := silk.DeleteHostGroup(name, timeout)
+		return err
+	}
+
 	if len(hostMapping) != 0 {
 		for _, h := range hostMapping {
-			_, err := silk.CreateHostHostGroupMapping(h.(interface{}).(string), name)
+			_, err := silk.CreateHostHostGroupMapping(h.(interface{}).(string), name, timeout)
 			if err != nil {
-				return diag.FromErr(err)
+				return createFailed(d, "Host Group", rollback, err)
 			}
+
+			addedHosts = append(addedHosts, h)
+			d.Set("host_mapping", addedHosts)
 		}
 	}
 
-	// Set the resource ID
-	d.SetId(fmt.Sprintf("silk-host-group-%d-%s", hostGroup.ID, strconv.FormatInt(time.Now().Unix(), 10)))
-
 	return resourceSilkHostGroupRead(ctx, d, m)
 }
 
@@ -102,9 +153,9 @@ func resourceSilkHostGroupRead(ctx context.Context, d *schema.ResourceData, m in
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
 	// name := d.Get("name").(string)
 
@@ -115,17 +166,23 @@ func resourceSilkHostGroupRead(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	for _, hostGroup := range getHostGroups.Hits {
-		if hostGroup.Name == d.Get("name").(string) {
+		if strconv.Itoa(hostGroup.ID) == d.Id() {
 
-			if len(d.Get("host_mapping").([]interface{})) != 0 {
+			hostMapping := d.Get("host_mapping").([]interface{})
+			mappingMode := listMappingMode(d.Get("mapping_mode").(string), hostMapping)
+
+			// In additive mode there is nothing to refresh until Terraform manages at least one host
+			if mappingMode == mappingModeAuthoritative || len(hostMapping) != 0 {
 
 				// Get the hosts in the host group and then set the TF host_mapping value with
-				// those responses
-				hostsInHostGroup, err := silk.GetHostGroupHosts(d.Get("name").(string))
+				// the hosts managed by Terraform
+				hostsInHostGroup, err := silk.GetHostGroupHosts(hostGroup.Name, timeout)
 				if err != nil {
 					return diag.FromErr(err)
 				}
 
+				hostsInHostGroup = managedNames(mappingMode, hostMapping, hostsInHostGroup)
+
 				// Sort the new slice to prevent any TF comparison issues
 				sort.Slice(hostsInHostGroup, func(i, j int) bool {
 					return hostsInHostGroup[i] < hostsInHostGroup[j]
@@ -153,15 +210,18 @@ func resourceSilkHostGroupRead(ctx context.Context, d *schema.ResourceData, m in
 
 func resourceSilkHostGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
 	config := map[string]interface{}{}
 	if d.HasChange("name") {
 		return diag.Errorf("Host Group names can not be changed.")
 	}
 
+	unlock := m.(*Client).lock(append(lockKeys(d, "host_mapping", hostLockKey), hostGroupLockKey(d.Get("name").(string)))...)
+	defer unlock()
+
 	hostMappingToRemove := []string{}
 	hostMappingToAdd := []string{}
 	if d.HasChange("host_mapping") {
@@ -200,7 +260,7 @@ func resourceSilkHostGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 
 		// Add each Host to the Volume
 		for _, h := range hostMappingToAdd {
-			_, err := silk.CreateHostHostGroupMapping(h, d.Get("name").(string))
+			_, err := silk.CreateHostHostGroupMapping(h, d.Get("name").(string), timeout)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -208,7 +268,7 @@ func resourceSilkHostGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 
 		// Remove each Host from the Volume
 		for _, h := range hostMappingToRemove {
-			_, err := silk.DeleteHostHostGroupMapping(h, d.Get("name").(string))
+			_, err := silk.DeleteHostHostGroupMapping(h, d.Get("name").(string), timeout)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -239,10 +299,14 @@ func resourceSilkHostGroupDelete(ctx context.Context, d *schema.ResourceData, m
 	var diags diag.Diagnostics
 
 	name := d.Get("name").(string)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
-	_, err := silk.DeleteHostGroup(name)
+	unlock := m.(*Client).lock(append(lockKeys(d, "host_mapping", hostLockKey), hostGroupLockKey(name))...)
+	defer unlock()
+
+	_, err := silk.DeleteHostGroup(name, timeout)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -254,43 +318,43 @@ func resourceSilkHostGroupDelete(ctx context.Context, d *schema.ResourceData, m
 
 func resourceSilkHostGroupImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 
-	timeout := d.Get("timeout").(int)
+	d.Set("timeout", 15)
+	d.Set("adopt_existing", false)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
-	name := d.Get("name").(string)
-
-	getHostGroups, err := silk.GetHostGroupByName(name, timeout)
+	getHostGroups, err := silk.GetHostGroups(timeout)
 	if err != nil {
 		return nil, err
 	}
 
+	// Host Groups can be imported by their SDP ID or by their name
 	for _, hostGroup := range getHostGroups.Hits {
-		if hostGroup.Name == d.Id() {
+		if importMatches(d.Id(), hostGroup.ID, hostGroup.Name) {
 
 			// Get the hosts in the host group and then set the TF host_mapping value with
 			// those responses
-			hostsInHostGroup, err := silk.GetHostGroupHosts(d.Id())
+			hostsInHostGroup, err := silk.GetHostGroupHosts(hostGroup.Name, timeout)
 			if err != nil {
 				return nil, err
 			}
 
 			// Sort the new slice to prevent any TF comparison issues
-			sort.Slice(hostsInHostGroup, func(i, j int) bool {
-				return hostsInHostGroup[i] < hostsInHostGroup[j]
-			})
+			sort.Strings(hostsInHostGroup)
 
 			d.Set("host_mapping", hostsInHostGroup)
+			d.Set("rollback_on_failure", false)
+			d.SetId(strconv.Itoa(hostGroup.ID))
 
-			d.Set("name", hostGroup.Name)
-			d.Set("description", hostGroup.Description)
-			d.Set("allow_different_host_types", hostGroup.AllowDifferentHostTypes)
-			d.Set("obj_id", hostGroup.ID)
-			d.Set("timeout", 15)
-			d.SetId(fmt.Sprintf("silk-host-group-%d-%s", hostGroup.ID, strconv.FormatInt(time.Now().Unix(), 10)))
-			// Stop the loop and return a nil err
+			diags := resourceSilkHostGroupRead(ctx, d, m)
+			if diags.HasError() {
+				return nil, diagsError(diags)
+			}
+
+			return []*schema.ResourceData{d}, nil
 		}
 	}
 
-	return []*schema.ResourceData{d}, nil
+	return nil, notFoundError("The server does not contain a Host Group with the ID or name '%s'", d.Id())
 }
@@ -2,7 +2,6 @@ package silk
 
 import (
 	"fmt"
-	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -38,6 +37,12 @@ func TestAccSilkHostGroup(t *testing.T) {
 					testAccCheckSilkHostGroupExists("silk_host_group.testacc"),
 				),
 			},
+			{
+				ResourceName:      "silk_host_group.testacc",
+				ImportState:       true,
+				ImportStateId:     hostGroupName,
+				ImportStateVerify: true,
+			},
 			{
 				Config: testAccCheckSilkHostGroupConfigRemoveMapping(hostGroupName),
 				Check: resource.ComposeTestCheckFunc(
@@ -166,7 +171,7 @@ func testAccCheckSilkHostGroupDestroy(s *terraform.State) error {
 	// Validate the Volume has been destroyed
 	_, err = silk.GetHostGroupID(hostGroupName)
 	if err != nil {
-		if strings.Contains(err.Error(), "The server does not contain") {
+		if notFound(err) {
 			return nil
 		}
 		return err
@@ -0,0 +1,11 @@
+package silk
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceSilkHostGroupVolumeMapping maps a single Volume to a Host Group. See resourceSilkVolumeMapping for the
+// implementation it shares with silk_host_volume_mapping.
+func resourceSilkHostGroupVolumeMapping() *schema.Resource {
+	return resourceSilkVolumeMapping(hostGroupMappingTarget)
+}
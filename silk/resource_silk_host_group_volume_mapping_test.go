@@ -0,0 +1,171 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// TestAccSilkHostGroupVolumeMapping is the main function that is executed during the test process.
+func TestAccSilkHostGroupVolumeMapping(t *testing.T) {
+
+	// Required Silk Centric Variables.
+	var volumeName = "TerraformTestAccHGMappingVolume"
+	var hostGroupName = "TerraformTestAccMappingHG"
+
+	var mappingID string
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSilkHostGroupVolumeMappingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSilkHostGroupVolumeMappingConfigBasic(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSilkHostGroupVolumeMappingExists("silk_host_group_volume_mapping.testacc", &mappingID),
+					resource.TestCheckResourceAttr("silk_host_group_volume_mapping.testacc", "volume_name", volumeName),
+					resource.TestCheckResourceAttr("silk_host_group_volume_mapping.testacc", "host_group_name", hostGroupName),
+					resource.TestCheckResourceAttrSet("silk_host_group_volume_mapping.testacc", "lun"),
+				),
+			},
+			{
+				ResourceName:      "silk_host_group_volume_mapping.testacc",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "silk_host_group_volume_mapping.testacc",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s:%s", volumeName, hostGroupName),
+				ImportStateVerify: true,
+			},
+			{
+				// Changing adopt_existing does not replace the mapping
+				Config: testAccCheckSilkHostGroupVolumeMappingConfigBasic(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("silk_host_group_volume_mapping.testacc", "id", &mappingID),
+					resource.TestCheckResourceAttr("silk_host_group_volume_mapping.testacc", "adopt_existing", "true"),
+				),
+			},
+		},
+	})
+}
+
+// TestHostGroupVolumeMappingRead validates the mapping is refreshed by its SDP ID, the names of the objects it joins
+// are read back, and a mapping of a Host with the same ID removes the mapping from the state
+func TestHostGroupVolumeMappingRead(t *testing.T) {
+	t.Parallel()
+
+	g := testGateway(t, testMappingHandler)
+	client := &Client{Credentials: silksdp.Connect(g.address(), "admin", "secret"), gateway: g}
+
+	r := resourceSilkHostGroupVolumeMapping()
+	d := r.TestResourceData()
+	d.SetId("8")
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "8" || d.Get("lun").(int) != 4 {
+		t.Errorf("expected the mapping 8 with the LUN 4, got the mapping '%s' with the LUN %d", d.Id(), d.Get("lun").(int))
+	}
+	if d.Get("volume_name").(string) != "volume1" || d.Get("host_group_name").(string) != "hostgroup1" {
+		t.Errorf("expected the mapping between volume1 and hostgroup1, got '%s' and '%s'", d.Get("volume_name").(string), d.Get("host_group_name").(string))
+	}
+
+	d.SetId("7")
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the mapping of a Host to be removed from the state, got the ID '%s'", d.Id())
+	}
+}
+
+// testAccCheckSilkHostGroupVolumeMappingConfigBasic returns a silk_host_group_volume_mapping resource along with the
+// Volume Group, Volume and Host Group it depends on
+func testAccCheckSilkHostGroupVolumeMappingConfigBasic(adoptExisting bool) string {
+	return fmt.Sprintf(`
+	resource "silk_volume_group" "testacc" {
+		name = "TerraformTestAccHGMappingVG"
+		quota_in_gb = 20
+		enable_deduplication = true
+		description = "Volume Group used for Terraform silk_host_group_volume_mapping Acceptance Testing"
+	}
+
+	resource "silk_volume" "testacc" {
+		name = "TerraformTestAccHGMappingVolume"
+		size_in_gb = 10
+		volume_group_name = silk_volume_group.testacc.name
+		description = "Volume used for Terraform silk_host_group_volume_mapping Acceptance Testing"
+		allow_destroy = true
+	}
+
+	resource "silk_host_group" "testacc" {
+		name = "TerraformTestAccMappingHG"
+		allow_different_host_types = false
+	}
+
+	resource "silk_host_group_volume_mapping" "testacc" {
+		volume_name = silk_volume.testacc.name
+		host_group_name = silk_host_group.testacc.name
+		adopt_existing = %t
+	}
+	`, adoptExisting)
+
+}
+
+// testAccCheckSilkHostGroupVolumeMappingExists validates the resource was executed successfully
+// by validating it exsits in the Terraform state. The ID of the mapping is saved to id.
+func testAccCheckSilkHostGroupVolumeMappingExists(n string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resources, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if resources.Primary.ID == "" {
+			return fmt.Errorf("No Host Group Volume Mapping set")
+		}
+
+		*id = resources.Primary.ID
+
+		return nil
+	}
+}
+
+// testAccCheckSilkHostGroupVolumeMappingDestroy verifies the mapping was sussuccessfully destroyed
+// by the terraform destroy process
+func testAccCheckSilkHostGroupVolumeMappingDestroy(s *terraform.State) error {
+
+	silk, err := silksdp.ConnectEnv()
+	if err != nil {
+		return err
+	}
+
+	mappings, err := silk.GetHostMappings(15)
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "silk_host_group_volume_mapping" {
+			continue
+		}
+
+		for _, mapping := range mappings {
+			if strconv.Itoa(mapping.ID) == rs.Primary.ID {
+				return fmt.Errorf("The mapping '%s' still exists on the server", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
@@ -2,7 +2,7 @@ package silk
 
 import (
 	"fmt"
-	"strings"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -36,6 +36,11 @@ func TestAccSilkHost(t *testing.T) {
 					testAccCheckSilkHostExists("silk_host.testacc"),
 				),
 			},
+			{
+				ResourceName:      "silk_host.testacc",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			{
 				Config: testAccCheckSilkHostConfigRemovePWWN(hostName),
 				Check: resource.ComposeTestCheckFunc(
@@ -46,6 +51,53 @@ func TestAccSilkHost(t *testing.T) {
 	})
 }
 
+// TestAccSilkHostRollback validates that a Host whose IQN fails to be added is removed, along with the PWWNs added
+// before it, when rollback_on_failure is set.
+func TestAccSilkHostRollback(t *testing.T) {
+
+	// Required Silk Centric Variables.
+	var hostName = "TerraformTestAccRollbackHost"
+	var pwwns = []string{"20:21:22:23:45:67:89:ac", "30:11:12:23:45:67:89:ac"}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			silk, err := silksdp.ConnectEnv()
+			if err != nil {
+				return err
+			}
+
+			// Validate the Host was removed by the rollback
+			_, err = silk.GetHostID(hostName)
+			if err == nil {
+				return fmt.Errorf("The Host '%s' was not rolled back", hostName)
+			}
+			if !notFound(err) {
+				return err
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckSilkHostConfigRollback(hostName, pwwns),
+				ExpectError: regexp.MustCompile("rollback_on_failure is set to true"),
+			},
+			{
+				// The PWWNs were released by the rollback, so they can be added to a new Host
+				Config: testAccCheckSilkHostConfigAddPWWN(hostName, pwwns),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSilkHostExists("silk_host.testacc"),
+					resource.TestCheckResourceAttr("silk_host.testacc", "pwwn.#", "2"),
+				),
+			},
+		},
+	})
+}
+
 // testAccCheckSilkHostConfigBasic returns a fully populated silk_host resource
 func testAccCheckSilkHostConfigBasic(name string, pwwn []string) string {
 	return fmt.Sprintf(`
@@ -84,6 +136,20 @@ func testAccCheckSilkHostConfigRemovePWWN(name string) string {
 
 }
 
+// testAccCheckSilkHostConfigRollback returns a silk_host resource with valid PWWNs and an IQN the Silk server rejects
+func testAccCheckSilkHostConfigRollback(name string, pwwn []string) string {
+	return fmt.Sprintf(`
+	resource "silk_host" "testacc" {
+		name = "%s"
+		host_type = "Linux"
+		pwwn = ["%s", "%s"]
+		iqn = "not-an-iqn"
+		rollback_on_failure = true
+	}
+	`, name, pwwn[0], pwwn[1])
+
+}
+
 // testAccCheckSilkHostExists validates the resource was executed successfully
 // by validating it exsits in the Terraform state
 func testAccCheckSilkHostExists(n string) resource.TestCheckFunc {
@@ -117,7 +183,7 @@ func testAccCheckSilkHostDestroy(s *terraform.State) error {
 	// Validate the Volume has been destroyed
 	_, err = silk.GetHostID(hostName)
 	if err != nil {
-		if strings.Contains(err.Error(), "The server does not contain") {
+		if notFound(err) {
 			return nil
 		}
 		return err
@@ -0,0 +1,11 @@
+package silk
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceSilkHostVolumeMapping maps a single Volume to a Host. See resourceSilkVolumeMapping for the implementation
+// it shares with silk_host_group_volume_mapping.
+func resourceSilkHostVolumeMapping() *schema.Resource {
+	return resourceSilkVolumeMapping(hostMappingTarget)
+}
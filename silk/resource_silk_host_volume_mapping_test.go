@@ -0,0 +1,225 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// TestAccSilkHostVolumeMapping is the main function that is executed during the test process.
+func TestAccSilkHostVolumeMapping(t *testing.T) {
+
+	// Required Silk Centric Variables.
+	var volumeName = "TerraformTestAccMappingVolume"
+	var hostName = "TerraformTestAccMappingHost"
+
+	var mappingID string
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSilkHostVolumeMappingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSilkHostVolumeMappingConfigBasic(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSilkHostVolumeMappingExists("silk_host_volume_mapping.testacc", &mappingID),
+					resource.TestCheckResourceAttr("silk_host_volume_mapping.testacc", "volume_name", volumeName),
+					resource.TestCheckResourceAttr("silk_host_volume_mapping.testacc", "host_name", hostName),
+					resource.TestCheckResourceAttrSet("silk_host_volume_mapping.testacc", "lun"),
+				),
+			},
+			{
+				ResourceName:      "silk_host_volume_mapping.testacc",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "silk_host_volume_mapping.testacc",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s:%s", volumeName, hostName),
+				ImportStateVerify: true,
+			},
+			{
+				// Changing adopt_existing does not replace the mapping
+				Config: testAccCheckSilkHostVolumeMappingConfigBasic(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("silk_host_volume_mapping.testacc", "id", &mappingID),
+					resource.TestCheckResourceAttr("silk_host_volume_mapping.testacc", "adopt_existing", "true"),
+				),
+			},
+		},
+	})
+}
+
+// testMappingHandler serves a Volume, a Host, a Host Group and the mappings between them to the mapping unit tests.
+// The mapping 9 maps a view to the Host. Deleting a mapping fails with a 404.
+func testMappingHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/api/v2/mappings":
+		w.Write([]byte(`{"hits": [
+			{"id": 7, "host": {"ref": "/hosts/1"}, "volume": {"ref": "/volumes/2"}, "lun": 3},
+			{"id": 8, "host": {"ref": "/host_groups/1"}, "volume": {"ref": "/volumes/2"}, "lun": 4},
+			{"id": 9, "host": {"ref": "/hosts/1"}, "volume": {"ref": "/snapshots/5"}, "lun": 5}
+		], "total": 3}`))
+	case "/api/v2/volumes":
+		w.Write([]byte(`{"hits": [{"id": 2, "name": "volume1"}], "total": 1}`))
+	case "/api/v2/hosts":
+		w.Write([]byte(`{"hits": [{"id": 1, "name": "host1"}], "total": 1}`))
+	case "/api/v2/host_groups":
+		w.Write([]byte(`{"hits": [{"id": 1, "name": "hostgroup1"}], "total": 1}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error_msg": "Not Found"}`))
+	}
+}
+
+// TestHostVolumeMappingRead validates the mapping is refreshed by its SDP ID, the names of the objects it joins are
+// read back, and an ID that no longer maps a Volume to a Host removes the mapping from the state. The mapping of a Host
+// Group is covered by TestHostGroupVolumeMappingRead.
+func TestHostVolumeMappingRead(t *testing.T) {
+	t.Parallel()
+
+	g := testGateway(t, testMappingHandler)
+	client := &Client{Credentials: silksdp.Connect(g.address(), "admin", "secret"), gateway: g}
+
+	r := resourceSilkHostVolumeMapping()
+	d := r.TestResourceData()
+	d.SetId("7")
+	d.Set("volume_name", "volume1")
+	d.Set("host_name", "host2")
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "7" || d.Get("lun").(int) != 3 {
+		t.Errorf("expected the mapping 7 with the LUN 3, got the mapping '%s' with the LUN %d", d.Id(), d.Get("lun").(int))
+	}
+	if d.Get("volume_name").(string) != "volume1" || d.Get("host_name").(string) != "host1" {
+		t.Errorf("expected the mapping between volume1 and host1, got '%s' and '%s'", d.Get("volume_name").(string), d.Get("host_name").(string))
+	}
+
+	// 9 maps a view and 10 does not exist
+	for _, id := range []string{"9", "10"} {
+		d.SetId(id)
+		if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if d.Id() != "" {
+			t.Errorf("expected the mapping %s to be removed from the state, got the ID '%s'", id, d.Id())
+		}
+	}
+}
+
+// TestHostVolumeMappingDelete validates a mapping that was already removed from the Silk server is deleted
+// successfully while any other failure is reported
+func TestHostVolumeMappingDelete(t *testing.T) {
+	t.Parallel()
+
+	g := testGateway(t, testMappingHandler)
+	client := &Client{Credentials: silksdp.Connect(g.address(), "admin", "secret"), gateway: g, locks: newMutexKV()}
+
+	r := resourceSilkHostVolumeMapping()
+	d := r.TestResourceData()
+	d.SetId("10")
+	d.Set("host_name", "host1")
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected the mapping to be removed from the state, got the ID '%s'", d.Id())
+	}
+
+	d.SetId("7")
+	if diags := r.DeleteContext(context.Background(), d, client); !diags.HasError() {
+		t.Errorf("expected the failure to delete the existing mapping 7 to be reported")
+	}
+}
+
+// testAccCheckSilkHostVolumeMappingConfigBasic returns a silk_host_volume_mapping resource along with the Volume
+// Group, Volume and Host it depends on
+func testAccCheckSilkHostVolumeMappingConfigBasic(adoptExisting bool) string {
+	return fmt.Sprintf(`
+	resource "silk_volume_group" "testacc" {
+		name = "TerraformTestAccMappingVG"
+		quota_in_gb = 20
+		enable_deduplication = true
+		description = "Volume Group used for Terraform silk_host_volume_mapping Acceptance Testing"
+	}
+
+	resource "silk_volume" "testacc" {
+		name = "TerraformTestAccMappingVolume"
+		size_in_gb = 10
+		volume_group_name = silk_volume_group.testacc.name
+		description = "Volume used for Terraform silk_host_volume_mapping Acceptance Testing"
+		allow_destroy = true
+	}
+
+	resource "silk_host" "testacc" {
+		name = "TerraformTestAccMappingHost"
+		host_type = "Linux"
+	}
+
+	resource "silk_host_volume_mapping" "testacc" {
+		volume_name = silk_volume.testacc.name
+		host_name = silk_host.testacc.name
+		adopt_existing = %t
+	}
+	`, adoptExisting)
+
+}
+
+// testAccCheckSilkHostVolumeMappingExists validates the resource was executed successfully
+// by validating it exsits in the Terraform state. The ID of the mapping is saved to id.
+func testAccCheckSilkHostVolumeMappingExists(n string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resources, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if resources.Primary.ID == "" {
+			return fmt.Errorf("No Host Volume Mapping set")
+		}
+
+		*id = resources.Primary.ID
+
+		return nil
+	}
+}
+
+// testAccCheckSilkHostVolumeMappingDestroy verifies the mapping was sussuccessfully destroyed
+// by the terraform destroy process
+func testAccCheckSilkHostVolumeMappingDestroy(s *terraform.State) error {
+
+	silk, err := silksdp.ConnectEnv()
+	if err != nil {
+		return err
+	}
+
+	mappings, err := silk.GetHostMappings(15)
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "silk_host_volume_mapping" {
+			continue
+		}
+
+		for _, mapping := range mappings {
+			if strconv.Itoa(mapping.ID) == rs.Primary.ID {
+				return fmt.Errorf("The mapping '%s' still exists on the server", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
@@ -2,17 +2,14 @@ package silk
 
 import (
 	"context"
-	"fmt"
 	"strconv"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
 )
 
 func resourceSilkRetentionPolicy() *schema.Resource {
-	return &schema.Resource{
+	return withIDStateUpgrader(&schema.Resource{
 		CreateContext: resourceSilkRetentionPolicyCreate,
 		ReadContext:   resourceSilkRetentionPolicyRead,
 		UpdateContext: resourceSilkRetentionPolicyUpdate,
@@ -20,6 +17,13 @@ func resourceSilkRetentionPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSilkRetentionPolicyImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultOperationTimeout),
+			Read:   schema.DefaultTimeout(defaultOperationTimeout),
+			Update: schema.DefaultTimeout(defaultOperationTimeout),
+			Delete: schema.DefaultTimeout(defaultOperationTimeout),
+		},
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -52,14 +56,21 @@ func resourceSilkRetentionPolicy() *schema.Resource {
 				Optional:    true,
 				Description: "Number of hours to retain the snapshot.",
 			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a Retention Policy with the same name that already exists on the Silk server is taken over instead of being created. Its attributes are updated to match the configuration.",
+			},
 			"timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     15,
+				Deprecated:  "Use the timeouts block and the request_timeout argument of the provider instead. This value is no longer used.",
 				Description: "The number of seconds to wait to establish a connection the Silk server before returning a timeout error.",
 			},
 		},
-	}
+	})
 
 }
 
@@ -67,13 +78,18 @@ func resourceSilkRetentionPolicyCreate(ctx context.Context, d *schema.ResourceDa
 
 	// Read in the resource schema arguments for easier assignment
 	name := d.Get("name").(string)
+
+	if adopted, diags := adoptExisting(ctx, resourceSilkRetentionPolicy(), d, m, "Retention Policy", name); adopted {
+		return diags
+	}
+
 	numSnapshots := d.Get("num_snapshots").(string)
 	weeks := d.Get("weeks").(string)
 	days := d.Get("days").(string)
 	hours := d.Get("hours").(string)
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
 	RetentionPolicy, err := silk.CreateRetentionPolicy(name, numSnapshots, weeks, days, hours, timeout)
 	if err != nil {
@@ -81,7 +97,7 @@ func resourceSilkRetentionPolicyCreate(ctx context.Context, d *schema.ResourceDa
 	}
 
 	// Set the resource ID
-	d.SetId(fmt.Sprintf("silk-RetentionPolicy-%d-%s", RetentionPolicy.ID, strconv.FormatInt(time.Now().Unix(), 10)))
+	d.SetId(strconv.Itoa(RetentionPolicy.ID))
 
 	return resourceSilkRetentionPolicyRead(ctx, d, m)
 }
@@ -91,9 +107,9 @@ func resourceSilkRetentionPolicyRead(ctx context.Context, d *schema.ResourceData
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
 	getRetentionPolicy, err := silk.GetRetentionPolicy(timeout)
 	if err != nil {
@@ -101,13 +117,14 @@ func resourceSilkRetentionPolicyRead(ctx context.Context, d *schema.ResourceData
 	}
 
 	for _, RetentionPolicy := range getRetentionPolicy.Hits {
-		if RetentionPolicy.Name == d.Get("name").(string) {
+		if strconv.Itoa(RetentionPolicy.ID) == d.Id() {
 
 			d.Set("name", RetentionPolicy.Name)
-			d.Set("num_snapshots", RetentionPolicy.NumSnapshots)
-			d.Set("weeks", RetentionPolicy.Weeks)
-			d.Set("days", RetentionPolicy.Days)
-			d.Set("hours", RetentionPolicy.Hours)
+			d.Set("obj_id", RetentionPolicy.ID)
+			d.Set("num_snapshots", retentionPolicyValue(d, "num_snapshots", RetentionPolicy.NumSnapshots))
+			d.Set("weeks", retentionPolicyValue(d, "weeks", RetentionPolicy.Weeks))
+			d.Set("days", retentionPolicyValue(d, "days", RetentionPolicy.Days))
+			d.Set("hours", retentionPolicyValue(d, "hours", RetentionPolicy.Hours))
 
 			// Stop the loop and return a nil err
 			return diags
@@ -122,9 +139,9 @@ func resourceSilkRetentionPolicyRead(ctx context.Context, d *schema.ResourceData
 
 func resourceSilkRetentionPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
 	config := map[string]interface{}{}
 	var RetentionPolicyName string
@@ -168,10 +185,11 @@ func resourceSilkRetentionPolicyDelete(ctx context.Context, d *schema.ResourceDa
 	var diags diag.Diagnostics
 
 	name := d.Get("name").(string)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
-	_, err := silk.DeleteRetentionPolicy(name)
+	_, err := silk.DeleteRetentionPolicy(name, timeout)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -182,28 +200,41 @@ func resourceSilkRetentionPolicyDelete(ctx context.Context, d *schema.ResourceDa
 }
 func resourceSilkRetentionPolicyImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 
-	timeout := d.Get("timeout").(int)
+	d.Set("timeout", 15)
+	d.Set("adopt_existing", false)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
 	getRetentionPolicy, err := silk.GetRetentionPolicy(timeout)
 	if err != nil {
 		return nil, err
 	}
 
+	// Retention Policies can be imported by their SDP ID or by their name
 	for _, RetentionPolicy := range getRetentionPolicy.Hits {
-		if RetentionPolicy.Name == d.Id() {
+		if importMatches(d.Id(), RetentionPolicy.ID, RetentionPolicy.Name) {
 
-			d.Set("name", RetentionPolicy.Name)
-			d.Set("num_snapshots", RetentionPolicy.NumSnapshots)
-			d.Set("weeks", RetentionPolicy.Weeks)
-			d.Set("days", RetentionPolicy.Days)
-			d.Set("hours", RetentionPolicy.Hours)
-			d.Set("obj_id", RetentionPolicy.ID)
-			d.Set("timeout", 15)
-			d.SetId(fmt.Sprintf("silk-RetentionPolicy-%d-%s", RetentionPolicy.ID, strconv.FormatInt(time.Now().Unix(), 10)))
+			d.SetId(strconv.Itoa(RetentionPolicy.ID))
+
+			diags := resourceSilkRetentionPolicyRead(ctx, d, m)
+			if diags.HasError() {
+				return nil, diagsError(diags)
+			}
+
+			return []*schema.ResourceData{d}, nil
 		}
 	}
 
-	return []*schema.ResourceData{d}, nil
+	return nil, notFoundError("The server does not contain a Retention Policy with the ID or name '%s'", d.Id())
+}
+
+// retentionPolicyValue converts a number returned by the API to the string stored in the schema. A value of 0 is
+// kept as an empty string when the argument was not set, to prevent a diff on every plan.
+func retentionPolicyValue(d *schema.ResourceData, key string, value int) string {
+	if value == 0 && d.Get(key).(string) == "" {
+		return ""
+	}
+
+	return strconv.Itoa(value)
 }
@@ -0,0 +1,288 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+func resourceSilkSnapshot() *schema.Resource {
+	return withIDStateUpgrader(&schema.Resource{
+		CreateContext: resourceSilkSnapshotCreate,
+		ReadContext:   resourceSilkSnapshotRead,
+		UpdateContext: resourceSilkSnapshotUpdate,
+		DeleteContext: resourceSilkSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSilkSnapshotImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultOperationTimeout),
+			Read:   schema.DefaultTimeout(defaultOperationTimeout),
+			Update: schema.DefaultTimeout(defaultOperationTimeout),
+			Delete: schema.DefaultTimeout(defaultOperationTimeout),
+		},
+		SchemaVersion: 1,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Snapshot.",
+			},
+			"obj_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The SDP ID of the Snapshot.",
+			},
+			"full_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The full SDP name of the Snapshot, in the form of '<volume group name>:<snapshot name>'.",
+			},
+			"volume_group_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Volume Group to snapshot.",
+			},
+			"retention_policy_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Retention Policy the Snapshot is retained under.",
+			},
+			"deletable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				ForceNew:    true,
+				Description: "When set to true, the Retention Policy is allowed to automatically delete the Snapshot once it expires.",
+			},
+			"exposable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "When set to true, views can be created from the Snapshot. A silk_volume_view can only be created from an exposable Snapshot.",
+			},
+			"creation_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time, in RFC 3339 format, the Snapshot was taken.",
+			},
+			"expiration_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time, in RFC 3339 format, the Snapshot expires under its Retention Policy. Empty when the policy does not expire snapshots.",
+			},
+			"size_in_gb": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The provisioned size, in GB, of the volumes in the Snapshot.",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a Snapshot with the same name that already exists on the Silk server is taken over instead of being created. Its attributes are updated to match the configuration.",
+			},
+		},
+	})
+
+}
+
+func resourceSilkSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Read in the resource schema arguments for easier assignment
+	name := d.Get("name").(string)
+	volumeGroupName := d.Get("volume_group_name").(string)
+
+	// Snapshots are named after their Volume Group on the server, in the form of <volume group name>:<snapshot name>
+	if adopted, diags := adoptExisting(ctx, resourceSilkSnapshot(), d, m, "Snapshot", fmt.Sprintf("%s:%s", volumeGroupName, name)); adopted {
+		return diags
+	}
+
+	retentionPolicyName := d.Get("retention_policy_name").(string)
+	deletable := d.Get("deletable").(bool)
+	exposable := d.Get("exposable").(bool)
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	unlock := m.(*Client).lock(volumeGroupLockKey(volumeGroupName))
+	defer unlock()
+
+	volumeGroupID, err := silk.GetVolumeGroupID(volumeGroupName, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	retentionPolicyID, err := silk.GetRetentionPolicyID(retentionPolicyName, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	snapshot, err := createSnapshot(silk, name, volumeGroupID, retentionPolicyID, deletable, exposable, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Set the resource ID
+	d.SetId(strconv.Itoa(snapshot.ID))
+	d.Set("obj_id", snapshot.ID)
+
+	return resourceSilkSnapshotRead(ctx, d, m)
+}
+
+func resourceSilkSnapshotRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	getSnapshot, err := getSnapshots(silk, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, snapshot := range getSnapshot.Hits {
+		if strconv.Itoa(snapshot.ID) == d.Id() && !snapshot.IsDeleted {
+
+			err := resourceSilkSnapshotSetData(d, silk, snapshot, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			// Stop the loop and return a nil err
+			return diags
+		}
+	}
+	// Snapshot was not found on the server
+	d.SetId("")
+
+	return diags
+
+}
+
+func resourceSilkSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// The /snapshots endpoint does not provide a PATCH method so every argument, except for adopt_existing, forces a new
+	// Snapshot.
+	return resourceSilkSnapshotRead(ctx, d, m)
+}
+
+func resourceSilkSnapshotDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	unlock := m.(*Client).lock(volumeGroupLockKey(d.Get("volume_group_name").(string)))
+	defer unlock()
+
+	err := deleteSnapshot(silk, d.Get("obj_id").(int), timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func resourceSilkSnapshotImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+
+	d.Set("adopt_existing", false)
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	getSnapshot, err := getSnapshots(silk, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Snapshots can be imported by their SDP ID or by their full SDP name, in the form of <volume group name>:<snapshot name>
+	for _, snapshot := range getSnapshot.Hits {
+		if importMatches(d.Id(), snapshot.ID, snapshot.Name) && !snapshot.IsDeleted && !snapshot.isView() {
+
+			err := resourceSilkSnapshotSetData(d, silk, snapshot, timeout)
+			if err != nil {
+				return nil, err
+			}
+
+			d.SetId(strconv.Itoa(snapshot.ID))
+
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, notFoundError("The server does not contain a Snapshot with the ID or name '%s'", d.Id())
+}
+
+// resourceSilkSnapshotSetData populates the Terraform state from the provided snapshot. Since the API shows the
+// Volume Group and Retention Policy as refs, both are converted back to their names.
+func resourceSilkSnapshotSetData(d *schema.ResourceData, silk *silksdp.Credentials, snapshot snapshot, timeout int) error {
+
+	volumeGroupID, err := refID(snapshot.VolumeGroup.Ref, "volume_groups")
+	if err != nil {
+		return err
+	}
+
+	getVolumeGroups, err := silk.GetVolumeGroups(timeout)
+	if err != nil {
+		return err
+	}
+
+	for _, volumeGroup := range getVolumeGroups.Hits {
+		if volumeGroup.ID == volumeGroupID {
+			d.Set("volume_group_name", volumeGroup.Name)
+		}
+	}
+
+	retentionPolicyID, err := refID(snapshot.RetentionPolicy.Ref, "retention_policies")
+	if err != nil {
+		return err
+	}
+
+	getRetentionPolicy, err := silk.GetRetentionPolicy(timeout)
+	if err != nil {
+		return err
+	}
+
+	creationTime := time.Unix(int64(snapshot.CreationTime), 0).UTC()
+	expirationTime := ""
+	for _, retentionPolicy := range getRetentionPolicy.Hits {
+		if retentionPolicy.ID == retentionPolicyID {
+			d.Set("retention_policy_name", retentionPolicy.Name)
+
+			// A Retention Policy without a weeks, days, or hours value keeps the Snapshot until it is
+			// removed by the num_snapshots limit or deleted manually
+			retention := time.Duration(retentionPolicy.Weeks*7*24+retentionPolicy.Days*24+retentionPolicy.Hours) * time.Hour
+			if retention != 0 {
+				expirationTime = creationTime.Add(retention).Format(time.RFC3339)
+			}
+		}
+	}
+
+	d.Set("name", snapshot.ShortName)
+	d.Set("obj_id", snapshot.ID)
+	d.Set("full_name", snapshot.Name)
+	d.Set("deletable", snapshot.IsAutoDeleteable)
+	d.Set("exposable", snapshot.IsExposable)
+	d.Set("creation_time", creationTime.Format(time.RFC3339))
+	d.Set("expiration_time", expirationTime)
+	d.Set("size_in_gb", snapshot.VolsnapsProvisionedCapacity/1024/1024) // Convert to GB
+
+	return nil
+}
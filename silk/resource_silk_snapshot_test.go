@@ -0,0 +1,127 @@
+package silk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// TestAccSilkSnapshot is the main function that is executed during the test process.
+func TestAccSilkSnapshot(t *testing.T) {
+
+	// Required Silk Centric Variables.
+	var snapshotName = "TerraformTestAccSnapshot"
+	var volumeGroupName = "TerraformTestAccSnapshotVG"
+	var retentionPolicyName = "TerraformTestAccSnapshotRP"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSilkSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSilkSnapshotConfigBasic(snapshotName, volumeGroupName, retentionPolicyName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSilkSnapshotExists("silk_snapshot.testacc"),
+					resource.TestCheckResourceAttr("silk_snapshot.testacc", "full_name", fmt.Sprintf("%s:%s", volumeGroupName, snapshotName)),
+					resource.TestCheckResourceAttrSet("silk_snapshot.testacc", "creation_time"),
+					resource.TestCheckResourceAttrSet("silk_snapshot.testacc", "expiration_time"),
+				),
+			},
+			{
+				Config: testAccCheckSilkSnapshotConfigBasic(snapshotName, volumeGroupName, retentionPolicyName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSilkSnapshotExists("silk_snapshot.testacc"),
+					resource.TestCheckResourceAttr("silk_snapshot.testacc", "exposable", "true"),
+				),
+			},
+			{
+				ResourceName:      "silk_snapshot.testacc",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s:%s", volumeGroupName, snapshotName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccCheckSilkSnapshotConfigBasic returns a silk_snapshot resource along with the Volume Group and
+// Retention Policy it depends on
+func testAccCheckSilkSnapshotConfigBasic(name, volumeGroupName, retentionPolicyName string, exposable bool) string {
+	return fmt.Sprintf(`
+	resource "silk_volume_group" "testacc" {
+		name = "%s"
+		quota_in_gb = 10
+		enable_deduplication = true
+		description = "Volume Group used for Terraform silk_snapshot Acceptance Testing"
+	}
+
+	resource "silk_retention_policy" "testacc" {
+		name = "%s"
+		num_snapshots = "5"
+		weeks = "0"
+		days = "1"
+		hours = "0"
+	}
+
+	resource "silk_snapshot" "testacc" {
+		name = "%s"
+		volume_group_name = silk_volume_group.testacc.name
+		retention_policy_name = silk_retention_policy.testacc.name
+		exposable = %t
+	}
+	`, volumeGroupName, retentionPolicyName, name, exposable)
+
+}
+
+// testAccCheckSilkSnapshotExists validates the resource was executed successfully
+// by validating it exsits in the Terraform state
+func testAccCheckSilkSnapshotExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resources, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if resources.Primary.ID == "" {
+			return fmt.Errorf("No Snapshot set")
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckSilkSnapshotDestroy verifies the Snapshot was sussuccessfully destroyed
+// by the terraform destroy process
+func testAccCheckSilkSnapshotDestroy(s *terraform.State) error {
+
+	silk, err := silksdp.ConnectEnv()
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := getSnapshots(silk, 15)
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "silk_snapshot" {
+			continue
+		}
+
+		for _, snapshot := range snapshots.Hits {
+			if fmt.Sprintf("%d", snapshot.ID) == rs.Primary.Attributes["obj_id"] && !snapshot.IsDeleted {
+				return fmt.Errorf("The Snapshot '%s' still exists on the server", snapshot.Name)
+			}
+		}
+	}
+
+	return nil
+}
@@ -3,19 +3,17 @@ package silk
 import (
 	"context"
 	"fmt"
-	"reflect"
-	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
 )
 
 func resourceSilkVolume() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		CreateContext: resourceSilkVolumeCreate,
 		ReadContext:   resourceSilkVolumeRead,
 		UpdateContext: resourceSilkVolumeUpdate,
@@ -23,6 +21,14 @@ func resourceSilkVolume() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSilkVolumeImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultOperationTimeout),
+			Read:   schema.DefaultTimeout(defaultOperationTimeout),
+			Update: schema.DefaultTimeout(defaultOperationTimeout),
+			Delete: schema.DefaultTimeout(defaultOperationTimeout),
+		},
+		CustomizeDiff: resourceSilkVolumeCustomizeDiff,
+		SchemaVersion: 2,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -36,9 +42,20 @@ func resourceSilkVolume() *schema.Resource {
 				Description: "The SDP ID of Volume.",
 			},
 			"size_in_gb": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "The size, in GB, of the Volume.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"size_in_gb", "size"},
+				Description:  "The size, in GB, of the Volume. Exactly one of size_in_gb or size must be set. A size that is not a whole number of GB is rounded down.",
+			},
+			"size": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"size_in_gb", "size"},
+				ValidateFunc:     validateCapacity,
+				DiffSuppressFunc: diffSuppressCapacity,
+				Description:      "The size of the Volume as a number followed by a unit (ex. 500GiB, 2TiB, or 1.5T). Exactly one of size_in_gb or size must be set.",
 			},
 			"volume_group_id": {
 				Type:        schema.TypeInt,
@@ -54,6 +71,7 @@ func resourceSilkVolume() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
+				ForceNew:    true,
 				Description: "This value corresponds to the 'VMware support' checkbox in the UI and specifies whether to enable VMFS.",
 			},
 			"description": {
@@ -73,28 +91,37 @@ func resourceSilkVolume() *schema.Resource {
 				Default:     false,
 				Description: "When set to true, this value will prevent the volume from being destroyed through Terraform.",
 			},
-			"host_mapping": {
-				Type:     schema.TypeList,
-				Required: false,
-				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Description: "An optional list of Hosts the Volume is mapped to.",
+			"rollback_on_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a Volume whose mappings fail to be created is removed, along with the mappings that were created, instead of being kept in the state as tainted.",
 			},
-			"host_group_mapping": {
-				Type:     schema.TypeList,
-				Required: false,
-				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Description: "An optional list of Host Groups the Volume is mapped to.",
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a Volume with the same name that already exists on the Silk server is taken over instead of being created. Its attributes are updated to match the configuration.",
+			},
+			"allow_shrink": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, this value will allow the size of the volume to be reduced. Shrinking a volume can destroy the data stored at the end of it.",
+			},
+			"host_mapping":       volumeMappingSchema("Volume", "Host"),
+			"host_group_mapping": volumeMappingSchema("Volume", "Host Group"),
+			"mapping_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{mappingModeAuthoritative, mappingModeAdditive}, false),
+				Description:  "Either `authoritative` or `additive`. In authoritative mode, Host and Host Group mappings made outside of Terraform are shown as drift and removed. In additive mode, they are ignored. When not set, host_mapping and host_group_mapping are each managed in authoritative mode once they hold at least one mapping, and ignored otherwise.",
 			},
 			"timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     15,
+				Deprecated:  "Use the timeouts block and the request_timeout argument of the provider instead. This value is no longer used.",
 				Description: "The number of seconds to wait to establish a connection the Silk server before returning a timeout error.",
 			},
 			"scsi_sn": {
@@ -104,49 +131,90 @@ func resourceSilkVolume() *schema.Resource {
 			},
 		},
 	}
+	resource.StateUpgraders = resourceSilkVolumeStateUpgraders(resource)
 
+	return resource
 }
 
 func resourceSilkVolumeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	// Read in the resource schema arguments for easier assignment
 	name := d.Get("name").(string)
-	sizeInGb := d.Get("size_in_gb").(int)
+
+	if adopted, diags := adoptExisting(ctx, resourceSilkVolume(), d, m, "Volume", name); adopted {
+		return diags
+	}
+
+	sizeInKiB, err := configuredSizeInKiB(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	volumeGroupName := d.Get("volume_group_name").(string)
 	vmware := d.Get("vmware").(bool)
 	description := d.Get("description").(string)
 	readOnly := d.Get("read_only").(bool)
 	hostMapping := d.Get("host_mapping").([]interface{})
 	hostGroupMapping := d.Get("host_group_mapping").([]interface{})
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
-	volume, err := silk.CreateVolume(name, sizeInGb, volumeGroupName, vmware, description, readOnly, timeout)
+	unlock := m.(*Client).lock(volumeLockKeys(d)...)
+	defer unlock()
+
+	volume, err := createVolume(silk, name, sizeInKiB, volumeGroupName, vmware, description, readOnly, timeout)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if len(hostMapping) != 0 {
-		for _, h := range hostMapping {
-			_, err := silk.CreateHostVolumeMapping(h.(interface{}).(string), name)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-		}
+	// Save the ID immediately so the Volume is tracked even when one of the mapping steps below fails
+	d.SetId(strconv.Itoa(volume.ID))
+
+	volumeRef := fmt.Sprintf("/volumes/%d", volume.ID)
+
+	// Track the mappings that have been created so a failure only records, or rolls back, those
+	createdHostMappings := []interface{}{}
+	createdHostGroupMappings := []interface{}{}
+	mappingFailed := func(err error) diag.Diagnostics {
+		d.Set("host_mapping", createdHostMappings)
+		d.Set("host_group_mapping", createdHostGroupMappings)
+
+		return createFailed(d, "Volume", func() error {
+			return rollbackVolume(silk, name, createdHostMappings, createdHostGroupMappings, timeout)
+		}, err)
 	}
 
-	if len(hostGroupMapping) != 0 {
-		for _, h := range hostGroupMapping {
-			_, err := silk.CreateHostGroupVolumeMapping(h.(interface{}).(string), name)
-			if err != nil {
-				return diag.FromErr(err)
-			}
+	// Map each Host to the Volume, using the requested LUN when one is provided
+	hostMappingToAdd, _ := volumeMappingChanges(nil, hostMapping, configuredLuns(d, "host_mapping"))
+	for _, h := range hostMappingToAdd {
+		ref, err := hostRef(silk, h.Name, timeout)
+		if err != nil {
+			return mappingFailed(err)
 		}
+
+		mapping, err := createMapping(silk, ref, volumeRef, h.Lun, timeout)
+		if err != nil {
+			return mappingFailed(err)
+		}
+
+		createdHostMappings = append(createdHostMappings, map[string]interface{}{"name": h.Name, "lun": mapping.Lun})
 	}
 
-	// Set the resource ID
-	d.SetId(fmt.Sprintf("silk-volume-%d-%s", volume.ID, strconv.FormatInt(time.Now().Unix(), 10)))
+	// Map each Host Group to the Volume, using the requested LUN when one is provided
+	hostGroupMappingToAdd, _ := volumeMappingChanges(nil, hostGroupMapping, configuredLuns(d, "host_group_mapping"))
+	for _, hg := range hostGroupMappingToAdd {
+		ref, err := hostGroupRef(silk, hg.Name, timeout)
+		if err != nil {
+			return mappingFailed(err)
+		}
+
+		mapping, err := createMapping(silk, ref, volumeRef, hg.Lun, timeout)
+		if err != nil {
+			return mappingFailed(err)
+		}
+
+		createdHostGroupMappings = append(createdHostGroupMappings, map[string]interface{}{"name": hg.Name, "lun": mapping.Lun})
+	}
 
 	return resourceSilkVolumeRead(ctx, d, m)
 }
@@ -156,9 +224,9 @@ func resourceSilkVolumeRead(ctx context.Context, d *schema.ResourceData, m inter
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
 	// name := d.Get("name").(string)
 
@@ -169,7 +237,7 @@ func resourceSilkVolumeRead(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	for _, volume := range getVolume.Hits {
-		if volume.Name == d.Get("name").(string) {
+		if strconv.Itoa(volume.ID) == d.Id() {
 			// Since the API shows the Volume Group as an ID, we have to strip the ID from the provided ref and then
 			// look up the volume name based off of that ID. From there we can run d.Set("volume_group_name")
 			volumeGroupRefID, err := strconv.Atoi(strings.Replace(volume.VolumeGroup.Ref, "/volume_groups/", "", 1))
@@ -192,45 +260,35 @@ func resourceSilkVolumeRead(ctx context.Context, d *schema.ResourceData, m inter
 				}
 			}
 
-			if len(d.Get("host_mapping").([]interface{})) != 0 {
+			hostMapping := d.Get("host_mapping").([]interface{})
+			hostGroupMapping := d.Get("host_group_mapping").([]interface{})
+			hostMappingMode := listMappingMode(d.Get("mapping_mode").(string), hostMapping)
+			hostGroupMappingMode := listMappingMode(d.Get("mapping_mode").(string), hostGroupMapping)
 
-				// Get the current hosts mapped to the volume then set the TF host_mapping value with
-				// those responses
-				hostsMappedToVolume, err := silk.GetVolumeHostMappings(d.Get("name").(string))
+			// In additive mode there is nothing to refresh until Terraform manages at least one mapping
+			if hostMappingMode == mappingModeAuthoritative || hostGroupMappingMode == mappingModeAuthoritative || len(hostMapping) != 0 || len(hostGroupMapping) != 0 {
+
+				// Get the current Hosts and Host Groups mapped to the volume, along with the LUN of each mapping, then
+				// set the TF host_mapping and host_group_mapping values with the mappings managed by Terraform
+				mappings, err := getMappings(silk, fmt.Sprintf("/volumes/%d", volume.ID), timeout)
 				if err != nil {
 					return diag.FromErr(err)
 				}
 
-				// Sort the new slice to prevent any TF comparison issues
-				sort.Slice(hostsMappedToVolume, func(i, j int) bool {
-					return hostsMappedToVolume[i] < hostsMappedToVolume[j]
-				})
-
-				d.Set("host_mapping", hostsMappedToVolume)
-
-			}
-
-			if len(d.Get("host_group_mapping").([]interface{})) != 0 {
-
-				// Get the current hosts mapped to the volume then set the TF host_mapping value with
-				// those responses
-				hostGroupsMappedToVolume, err := silk.GetVolumeHostGroupMappings(d.Get("name").(string))
+				hostMappings, hostGroupMappings, err := flattenMappings(silk, mappings, timeout)
 				if err != nil {
 					return diag.FromErr(err)
 				}
 
-				// Sort the new slice to prevent any TF comparison issues
-				sort.Slice(hostGroupsMappedToVolume, func(i, j int) bool {
-					return hostGroupsMappedToVolume[i] < hostGroupsMappedToVolume[j]
-				})
-
-				d.Set("host_group_mapping", hostGroupsMappedToVolume)
+				d.Set("host_mapping", refreshedVolumeMappings(hostMappingMode, hostMapping, hostMappings))
+				d.Set("host_group_mapping", refreshedVolumeMappings(hostGroupMappingMode, hostGroupMapping, hostGroupMappings))
 
 			}
 
 			d.Set("name", volume.Name)
 			d.Set("obj_id", volume.ID)
 			d.Set("size_in_gb", volume.Size/1024/1024) // Convert to GB
+			d.Set("size", formatCapacity(volume.Size))
 
 			d.Set("vmware", volume.VmwareSupport)
 			d.Set("description", volume.Description)
@@ -251,9 +309,9 @@ func resourceSilkVolumeRead(ctx context.Context, d *schema.ResourceData, m inter
 
 func resourceSilkVolumeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
 	config := map[string]interface{}{}
 	var currentVolumeName string
@@ -286,51 +344,33 @@ func resourceSilkVolumeUpdate(ctx context.Context, d *schema.ResourceData, m int
 		currentVolumeName = d.Get("name").(string)
 	}
 
-	hostMappingToRemove := []string{}
-	hostMappingToAdd := []string{}
+	unlock := m.(*Client).lock(volumeLockKeys(d)...)
+	defer unlock()
+
+	volumeRef := fmt.Sprintf("/volumes/%s", d.Id())
+
 	if d.HasChange("host_mapping") {
 
 		// Get the current (c) and new (n) host mappings
 		c, n := d.GetChange("host_mapping")
-		// Use reflection to convert c and n into values that can be ranged through
-		// without crashing Terraform
-		cReflect := reflect.ValueOf(c)
-		nReflect := reflect.ValueOf(n)
-		// Create new []string{}, that holds the values of c, n, that can be ranged
-		// through
-		current := []string{}
-		new := []string{}
-		// Loop through cReflect and append values to current
-		for i := 0; i < cReflect.Len(); i++ {
-			current = append(current, cReflect.Index(i).Interface().(string))
-		}
-		// Loop through nReflect and append values to new
-		for i := 0; i < nReflect.Len(); i++ {
-			new = append(new, nReflect.Index(i).Interface().(string))
-		}
-
-		union := unique(append(current, new...))
-		for _, h := range union {
-			_, foundInNew := find(new, h)
-			_, foundInCurrent := find(current, h)
-			if foundInNew && !foundInCurrent {
-				hostMappingToAdd = append(hostMappingToAdd, h)
-			} else if !foundInNew && foundInCurrent {
-				hostMappingToRemove = append(hostMappingToRemove, h)
+		hostMappingToAdd, hostMappingToRemove := volumeMappingChanges(c.([]interface{}), n.([]interface{}), configuredLuns(d, "host_mapping"))
+
+		// Remove each Host from the Volume. Hosts whose LUN changed are removed before being mapped again.
+		for _, h := range hostMappingToRemove {
+			_, err := silk.DeleteHostVolumeMapping(h, currentVolumeName, timeout)
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 
 		// Add each Host to the Volume
 		for _, h := range hostMappingToAdd {
-			_, err := silk.CreateHostVolumeMapping(h, currentVolumeName)
+			ref, err := hostRef(silk, h.Name, timeout)
 			if err != nil {
 				return diag.FromErr(err)
 			}
-		}
 
-		// Remove each Host from the Volume
-		for _, h := range hostMappingToRemove {
-			_, err := silk.DeleteHostVolumeMapping(h, currentVolumeName)
+			_, err = createMapping(silk, ref, volumeRef, h.Lun, timeout)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -338,51 +378,29 @@ func resourceSilkVolumeUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 	}
 
-	hostGroupMappingToRemove := []string{}
-	hostGroupMappingToAdd := []string{}
 	if d.HasChange("host_group_mapping") {
 
 		// Get the current (c) and new (n) host group mappings
 		c, n := d.GetChange("host_group_mapping")
-		// Use reflection to convert c and n into values that can be ranged through
-		// without crashing Terraform
-		cReflect := reflect.ValueOf(c)
-		nReflect := reflect.ValueOf(n)
-		// Create new []string{}, that holds the values of c, n, that can be ranged
-		// through
-		current := []string{}
-		new := []string{}
-		// Loop through cReflect and append values to current
-		for i := 0; i < cReflect.Len(); i++ {
-			current = append(current, cReflect.Index(i).Interface().(string))
-		}
-		// Loop through nReflect and append values to new
-		for i := 0; i < nReflect.Len(); i++ {
-			new = append(new, nReflect.Index(i).Interface().(string))
-		}
-
-		union := unique(append(current, new...))
-		for _, h := range union {
-			_, foundInNew := find(new, h)
-			_, foundInCurrent := find(current, h)
-			if foundInNew && !foundInCurrent {
-				hostGroupMappingToAdd = append(hostGroupMappingToAdd, h)
-			} else if !foundInNew && foundInCurrent {
-				hostGroupMappingToRemove = append(hostGroupMappingToRemove, h)
+		hostGroupMappingToAdd, hostGroupMappingToRemove := volumeMappingChanges(c.([]interface{}), n.([]interface{}), configuredLuns(d, "host_group_mapping"))
+
+		// Remove each Host Group mapping from the Volume. Host Groups whose LUN changed are removed before being
+		// mapped again.
+		for _, hg := range hostGroupMappingToRemove {
+			_, err := silk.DeleteHostGroupVolumeMapping(hg, currentVolumeName, timeout)
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
 
 		// Map each Host Group to the Volume
 		for _, hg := range hostGroupMappingToAdd {
-			_, err := silk.CreateHostGroupVolumeMapping(hg, currentVolumeName)
+			ref, err := hostGroupRef(silk, hg.Name, timeout)
 			if err != nil {
 				return diag.FromErr(err)
 			}
-		}
 
-		// Remove each Host Group mapping from the Volume
-		for _, hg := range hostGroupMappingToRemove {
-			_, err := silk.DeleteHostGroupVolumeMapping(hg, currentVolumeName)
+			_, err = createMapping(silk, ref, volumeRef, hg.Lun, timeout)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -390,8 +408,13 @@ func resourceSilkVolumeUpdate(ctx context.Context, d *schema.ResourceData, m int
 
 	}
 
-	if d.HasChange("size_in_gb") {
-		config["size"] = d.Get("size_in_gb").(int) * 1024 * 1024
+	if d.HasChange("size_in_gb") || d.HasChange("size") {
+		sizeInKiB, err := configuredSizeInKiB(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		config["size"] = sizeInKiB
 	}
 
 	if d.HasChange("volume_group_name") {
@@ -410,11 +433,6 @@ func resourceSilkVolumeUpdate(ctx context.Context, d *schema.ResourceData, m int
 		}
 	}
 
-	if d.HasChange("vmware") {
-		// config["vmware_support"] = d.Get("vmware").(bool)
-		return diag.Errorf("Updating the `vmware` field is not supported")
-	}
-
 	if d.HasChange("description") {
 		config["description"] = d.Get("description").(string)
 	}
@@ -446,15 +464,17 @@ func resourceSilkVolumeDelete(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	name := d.Get("name").(string)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
+
+	unlock := m.(*Client).lock(volumeLockKeys(d)...)
+	defer unlock()
 
 	// Delete host_mappings before remove volume
 	currentHostMappings, _ := d.GetChange("host_mapping")
-	currentHostMappingsReflect := reflect.ValueOf(currentHostMappings)
-	for i := 0; i < currentHostMappingsReflect.Len(); i++ {
-		hostMappingToRemove := currentHostMappingsReflect.Index(i).Interface().(string)
-		_, err := silk.DeleteHostVolumeMapping(hostMappingToRemove, d.Get("name").(string))
+	for _, h := range expandVolumeMappings(currentHostMappings.([]interface{})) {
+		_, err := silk.DeleteHostVolumeMapping(h.Name, d.Get("name").(string), timeout)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -462,16 +482,14 @@ func resourceSilkVolumeDelete(ctx context.Context, d *schema.ResourceData, m int
 
 	// Delete host_group_mappings before remove volume
 	currentHostGroupMappings, _ := d.GetChange("host_group_mapping")
-	currentHostGroupMappingsReflect := reflect.ValueOf(currentHostGroupMappings)
-	for i := 0; i < currentHostGroupMappingsReflect.Len(); i++ {
-		hostGroupMappingToRemove := currentHostGroupMappingsReflect.Index(i).Interface().(string)
-		_, err := silk.DeleteHostGroupVolumeMapping(hostGroupMappingToRemove, d.Get("name").(string))
+	for _, hg := range expandVolumeMappings(currentHostGroupMappings.([]interface{})) {
+		_, err := silk.DeleteHostGroupVolumeMapping(hg.Name, d.Get("name").(string), timeout)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
-	_, err := silk.DeleteVolume(name)
+	_, err := silk.DeleteVolume(name, timeout)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -483,78 +501,380 @@ func resourceSilkVolumeDelete(ctx context.Context, d *schema.ResourceData, m int
 
 func resourceSilkVolumeImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 
-	timeout := d.Get("timeout").(int)
-
-	silk := m.(*silksdp.Credentials)
+	d.Set("timeout", 15)
+	d.Set("adopt_existing", false)
+	timeout := requestTimeout(ctx)
 
-	name := d.Get("name").(string)
+	silk := m.(*Client).credentials(ctx, d)
 
-	getVolume, err := silk.GetVolumeByName(name, timeout)
+	getVolume, err := silk.GetVolumes(timeout)
 	if err != nil {
 		return nil, err
 	}
 
+	// Volumes can be imported by their SDP ID or by their name
 	for _, volume := range getVolume.Hits {
-		if volume.Name == d.Id() {
-			// Since the API shows the Volume Group as an ID, we have to strip the ID from the provided ref and then
-			// look up the volume name based off of that ID. From there we can run d.Set("volume_group_name")
-			volumeGroupRefID, err := strconv.Atoi(strings.Replace(volume.VolumeGroup.Ref, "/volume_groups/", "", 1))
-			// If any error occured while getting the volumes volume group id, set the volume group id to blank since we can
-			// assume there is not one present
+		if importMatches(d.Id(), volume.ID, volume.Name) {
+
+			// Get the current hosts and host groups mapped to the volume then set the TF host_mapping and
+			// host_group_mapping values with those responses
+			mappings, err := getMappings(silk, fmt.Sprintf("/volumes/%d", volume.ID), timeout)
 			if err != nil {
-				d.Set("volume_group_name", "")
+				return nil, err
 			}
 
-			// Get the current volume groups on the server
-			getVolumeGroups, err := silk.GetVolumeGroups(timeout)
+			hostMappings, hostGroupMappings, err := flattenMappings(silk, mappings, timeout)
 			if err != nil {
 				return nil, err
 			}
 
-			for _, volumeGroup := range getVolumeGroups.Hits {
-				if volumeGroup.ID == volumeGroupRefID {
-					d.Set("volume_group_name", volumeGroup.Name)
-				}
+			d.Set("host_mapping", hostMappings)
+			d.Set("host_group_mapping", hostGroupMappings)
+			d.Set("allow_destroy", false)
+			d.Set("allow_shrink", false)
+			d.Set("rollback_on_failure", false)
+			d.SetId(strconv.Itoa(volume.ID))
+
+			diags := resourceSilkVolumeRead(ctx, d, m)
+			if diags.HasError() {
+				return nil, diagsError(diags)
 			}
 
-			// Get the current hosts mapped to the volume then set the TF host_mapping value with
-			// those responses
-			hostsMappedToVolume, err := silk.GetVolumeHostMappings(d.Id())
-			if err == nil {
-				sort.Slice(hostsMappedToVolume, func(i, j int) bool {
-					return hostsMappedToVolume[i] < hostsMappedToVolume[j]
-				})
+			return []*schema.ResourceData{d}, nil
+		}
+	}
 
-				d.Set("host_mapping", hostsMappedToVolume)
-			}
+	return nil, notFoundError("The server does not contain a Volume with the ID or name '%s'", d.Id())
+}
 
-			// Get the current hosts mapped to the volume then set the TF host_mapping value with
-			// those responses
-			hostGroupsMappedToVolume, err := silk.GetVolumeHostGroupMappings(d.Id())
-			if err == nil {
-				sort.Slice(hostGroupsMappedToVolume, func(i, j int) bool {
-					return hostGroupsMappedToVolume[i] < hostGroupsMappedToVolume[j]
-				})
+// rollbackVolume removes the provided mappings and then the Volume itself after a failed create.
+func rollbackVolume(silk *silksdp.Credentials, name string, hostMappings, hostGroupMappings []interface{}, timeout int) error {
+
+	for _, h := range expandVolumeMappings(hostMappings) {
+		_, err := silk.DeleteHostVolumeMapping(h.Name, name, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, hg := range expandVolumeMappings(hostGroupMappings) {
+		_, err := silk.DeleteHostGroupVolumeMapping(hg.Name, name, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := silk.DeleteVolume(name, timeout)
+
+	return err
+}
+
+// resourceGetter is implemented by both *schema.ResourceData and *schema.ResourceDiff so the same helpers can be used
+// while applying and while planning.
+type resourceGetter interface {
+	Id() string
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+	HasChange(key string) bool
+}
 
-				d.Set("host_group_mapping", hostGroupsMappedToVolume)
+// configuredSizeInKiB returns the size of the Volume in KiB from whichever of size or size_in_gb is configured. Since both
+// attributes are Computed, the one that changed takes precedence.
+func configuredSizeInKiB(d resourceGetter) (int, error) {
+	if size := d.Get("size").(string); size != "" && (d.Id() == "" || d.HasChange("size")) {
+		return parseCapacity(size)
+	}
+
+	return d.Get("size_in_gb").(int) * 1024 * 1024, nil
+}
+
+// resourceSilkVolumeCustomizeDiff validates a change to the size of the Volume at plan time. Shrinking the Volume is
+// only allowed when allow_shrink is set, and the new size must fit within the quota of the Volume Group.
+func resourceSilkVolumeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+
+	// The size can not be validated until every value it depends on is known. When the Volume is created, the size
+	// attribute that is not configured is always unknown.
+	sizeKnown := d.NewValueKnown("size") && d.NewValueKnown("size_in_gb")
+	if d.Id() == "" {
+		sizeKnown = d.NewValueKnown("size") || d.NewValueKnown("size_in_gb")
+	}
+
+	if !sizeKnown || !d.NewValueKnown("volume_group_name") {
+		return nil
+	}
+
+	if d.Id() != "" && !d.HasChange("size") && !d.HasChange("size_in_gb") && !d.HasChange("volume_group_name") {
+		return nil
+	}
+
+	newSizeInKiB, err := configuredSizeInKiB(d)
+	if err != nil {
+		return err
+	}
+
+	currentSizeInKiB := 0
+	if d.Id() != "" {
+		currentSize, _ := d.GetChange("size")
+		currentSizeInGb, _ := d.GetChange("size_in_gb")
+		if currentSize.(string) != "" {
+			currentSizeInKiB, err = parseCapacity(currentSize.(string))
+			if err != nil {
+				return err
 			}
-			// Sort the new slice to prevent any TF comparison issues
+		} else {
+			currentSizeInKiB = currentSizeInGb.(int) * 1024 * 1024
+		}
 
-			d.Set("name", volume.Name)
-			d.Set("obj_id", volume.ID)
-			d.Set("size_in_gb", volume.Size/1024/1024) // Convert to GB
+		if newSizeInKiB < currentSizeInKiB && !d.Get("allow_shrink").(bool) {
+			return fmt.Errorf("The Volume can not be shrunk from %s to %s unless `allow_shrink` is set to true", formatCapacity(currentSizeInKiB), formatCapacity(newSizeInKiB))
+		}
 
-			d.Set("vmware", volume.VmwareSupport)
-			d.Set("description", volume.Description)
-			d.Set("read_only", volume.ReadOnly)
-			d.Set("allow_destroy", d.Get("allow_destroy").(bool))
-			d.Set("scsi_sn", volume.ScsiSn)
-			d.Set("timeout", 15)
-			d.SetId(fmt.Sprintf("silk-volume-%d-%s", volume.ID, strconv.FormatInt(time.Now().Unix(), 10)))
+		// Keep the attribute that was not changed in sync with the new size
+		if d.HasChange("size") {
+			d.SetNew("size_in_gb", newSizeInKiB/1024/1024)
+		} else if d.HasChange("size_in_gb") {
+			d.SetNew("size", formatCapacity(newSizeInKiB))
+		}
+	}
 
-			// Stop the loop and return a nil err
+	silk := m.(*Client).credentials(ctx, d)
+
+	volumeGroupName := d.Get("volume_group_name").(string)
+
+	getVolumeGroups, err := silk.GetVolumeGroups(requestTimeout(ctx))
+	if err != nil {
+		return err
+	}
+
+	for _, volumeGroup := range getVolumeGroups.Hits {
+		if volumeGroup.Name != volumeGroupName {
+			continue
+		}
+
+		// A quota of 0 corresponds to an Unlimited Quota
+		quotaInKiB := volumeGroupQuotaInKiB(volumeGroup.Quota)
+		if quotaInKiB == 0 {
+			return nil
+		}
+
+		// The current size of the Volume is already part of the provisioned capacity when it is not moving to
+		// another Volume Group
+		provisionedInKiB := int(volumeGroup.VolumesProvisionedCapacity) + newSizeInKiB
+		if !d.HasChange("volume_group_name") {
+			provisionedInKiB -= currentSizeInKiB
+		}
+
+		if provisionedInKiB > quotaInKiB {
+			return fmt.Errorf("A Volume size of %s exceeds the quota of the Volume Group '%s'. The Volume Group has %s provisioned out of a %s quota", formatCapacity(newSizeInKiB), volumeGroupName, formatCapacity(int(volumeGroup.VolumesProvisionedCapacity)), formatCapacity(quotaInKiB))
+		}
+
+		return nil
+	}
+
+	// The Volume Group does not exist yet and will be validated by the SDP during the apply
+	return nil
+}
+
+// volumeLockKeys returns the lock keys of the objects changed by an operation on a Volume: the current and new Volume
+// Group it is a member of, and the Hosts and Host Groups it is mapped to.
+func volumeLockKeys(d *schema.ResourceData) []string {
+	keys := mappingLockKeys(d)
+
+	// The current Volume Group is empty when the Volume is created
+	c, n := d.GetChange("volume_group_name")
+	for _, name := range []string{c.(string), n.(string)} {
+		if name != "" {
+			keys = append(keys, volumeGroupLockKey(name))
+		}
+	}
+
+	return keys
+}
+
+// volumeMappingSchema returns the schema of the host_mapping or host_group_mapping blocks of a Volume or View, each
+// holding the name of a Host or Host Group (objectType) and the LUN of the mapping.
+func volumeMappingSchema(volumeType, objectType string) *schema.Schema {
+	lunDescription := fmt.Sprintf("The LUN the %s is presented to the Host as.", volumeType)
+	if objectType == "Host Group" {
+		lunDescription = fmt.Sprintf("The LUN the %s is presented to the Hosts in the Host Group as.", volumeType)
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: false,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: fmt.Sprintf("The name of the %s.", objectType),
+				},
+				"lun": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  lunDescription + " When not set, the SDP assigns the next available LUN.",
+				},
+			},
+		},
+		Description: fmt.Sprintf("An optional list of %ss the %s is mapped to.", objectType, volumeType),
+	}
+}
+
+// unassignedLun is the LUN of a mapping whose LUN was not requested, which lets the SDP assign the next available LUN
+const unassignedLun = -1
+
+// volumeMapping is a single host_mapping or host_group_mapping block of a Volume or View.
+type volumeMapping struct {
+	Name string
+	Lun  int
+}
+
+// expandVolumeMappings converts host_mapping or host_group_mapping blocks into volumeMappings.
+func expandVolumeMappings(blocks []interface{}) []volumeMapping {
+	mappings := []volumeMapping{}
+	for _, block := range blocks {
+		if block == nil {
+			continue
+		}
+
+		value := block.(map[string]interface{})
+		lun, _ := value["lun"].(int)
+		mappings = append(mappings, volumeMapping{Name: value["name"].(string), Lun: lun})
+	}
+
+	return mappings
+}
+
+// managedVolumeMappings returns the host_mapping or host_group_mapping blocks found on the server that are managed by
+// Terraform. See managedNames for how the mapping mode is applied.
+func managedVolumeMappings(mappingMode string, state []interface{}, server []interface{}) []interface{} {
+	stateNames := []interface{}{}
+	for _, mapping := range expandVolumeMappings(state) {
+		stateNames = append(stateNames, mapping.Name)
+	}
+
+	serverNames := []string{}
+	for _, mapping := range expandVolumeMappings(server) {
+		serverNames = append(serverNames, mapping.Name)
+	}
+
+	managed := managedNames(mappingMode, stateNames, serverNames)
+
+	blocks := []interface{}{}
+	for _, block := range server {
+		if _, found := find(managed, block.(map[string]interface{})["name"].(string)); found {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks
+}
+
+// refreshedVolumeMappings returns the host_mapping or host_group_mapping blocks of a refreshed Volume: the blocks
+// found on the server that are managed by Terraform, in the order of the blocks in the state. The server returns the
+// mappings sorted by name, which would otherwise show a plan for every configuration that is not sorted.
+func refreshedVolumeMappings(mappingMode string, state []interface{}, server []interface{}) []interface{} {
+	return orderedVolumeMappings(state, managedVolumeMappings(mappingMode, state, server))
+}
+
+// orderedVolumeMappings returns the host_mapping or host_group_mapping blocks found on the server in the order of the
+// blocks in the state. See orderedNames for where the other blocks are placed.
+func orderedVolumeMappings(state []interface{}, server []interface{}) []interface{} {
+	stateNames := []interface{}{}
+	for _, mapping := range expandVolumeMappings(state) {
+		stateNames = append(stateNames, mapping.Name)
+	}
+
+	serverNames := []string{}
+	serverBlocks := map[string]interface{}{}
+	for _, block := range server {
+		name := block.(map[string]interface{})["name"].(string)
+		serverNames = append(serverNames, name)
+		serverBlocks[name] = block
+	}
+
+	blocks := []interface{}{}
+	for _, name := range orderedNames(stateNames, serverNames) {
+		blocks = append(blocks, serverBlocks[name])
+	}
+
+	return blocks
+}
+
+// configuredLuns returns the names of the host_mapping or host_group_mapping blocks whose lun is set in the
+// configuration. Since lun is Optional and Computed, a block without a lun inherits the LUN stored in the state, so
+// the raw configuration is the only way to tell a requested LUN apart. nil is returned when the configuration is not
+// available.
+func configuredLuns(d *schema.ResourceData, attribute string) map[string]bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute(attribute) {
+		return nil
+	}
+
+	configured := map[string]bool{}
+	blocks := config.GetAttr(attribute)
+	if blocks.IsNull() || !blocks.IsKnown() {
+		return configured
+	}
+
+	for it := blocks.ElementIterator(); it.Next(); {
+		_, block := it.Element()
+		name := block.GetAttr("name")
+		if name.IsNull() || !name.IsKnown() {
+			continue
+		}
+		configured[name.AsString()] = !block.GetAttr("lun").IsNull()
+	}
+
+	return configured
+}
+
+// volumeMappingChanges compares the current (c) and new (n) host_mapping or host_group_mapping blocks and returns
+// the mappings to create and the names of the mappings to remove. A mapping whose requested LUN differs from the
+// LUN the SDP assigned is both removed and created again. Only the LUNs of the names in configured are requested,
+// and the mappings to create whose LUN is not requested get unassignedLun. When configured is nil, every LUN other
+// than 0, which can not be told apart from an unset LUN without the configuration, is requested.
+func volumeMappingChanges(c, n []interface{}, configured map[string]bool) ([]volumeMapping, []string) {
+	current := expandVolumeMappings(c)
+	new := expandVolumeMappings(n)
+
+	currentLuns := map[string]int{}
+	for _, mapping := range current {
+		currentLuns[mapping.Name] = mapping.Lun
+	}
+
+	toAdd := []volumeMapping{}
+	toRemove := []string{}
+	newNames := []string{}
+	for _, mapping := range new {
+		newNames = append(newNames, mapping.Name)
+
+		// A LUN that is not configured was inherited from the state and was not requested
+		requested := mapping.Lun != 0
+		if configured != nil {
+			requested = configured[mapping.Name]
+		}
+		if !requested {
+			mapping.Lun = unassignedLun
+		}
+
+		currentLun, found := currentLuns[mapping.Name]
+		if !found {
+			toAdd = append(toAdd, mapping)
+		} else if mapping.Lun != unassignedLun && mapping.Lun != currentLun {
+			toRemove = append(toRemove, mapping.Name)
+			toAdd = append(toAdd, mapping)
+		}
+	}
+
+	for _, mapping := range current {
+		if _, found := find(newNames, mapping.Name); !found {
+			toRemove = append(toRemove, mapping.Name)
 		}
 	}
 
-	return []*schema.ResourceData{d}, nil
+	return toAdd, toRemove
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,14 +12,22 @@ import (
 )
 
 func resourceSilkVolumeGroup() *schema.Resource {
-	return &schema.Resource{
+	return withIDStateUpgrader(&schema.Resource{
 		CreateContext: resourceSilkVolumeGroupCreate,
 		ReadContext:   resourceSilkVolumeGroupRead,
 		UpdateContext: resourceSilkVolumeGroupUpdate,
 		DeleteContext: resourceSilkVolumeGroupDelete,
+		CustomizeDiff: resourceSilkVolumeGroupCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSilkVolumeGroupImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultOperationTimeout),
+			Read:   schema.DefaultTimeout(defaultOperationTimeout),
+			Update: schema.DefaultTimeout(defaultOperationTimeout),
+			Delete: schema.DefaultTimeout(defaultOperationTimeout),
+		},
+		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -34,15 +41,26 @@ func resourceSilkVolumeGroup() *schema.Resource {
 				Description: "The SDP ID of Volume Group.",
 			},
 			"quota_in_gb": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     0,
-				Description: "The size quota, in GB, of the Volume Group. The default option of 0 corresponds to an Unlimited Quota.",
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"quota"},
+				Description:   "The size quota, in GB, of the Volume Group. The default option of 0 corresponds to an Unlimited Quota. A quota that is not a whole number of GB is rounded down.",
+			},
+			"quota": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"quota_in_gb"},
+				ValidateFunc:     validateCapacity,
+				DiffSuppressFunc: diffSuppressCapacity,
+				Description:      "The size quota of the Volume Group as a number followed by a unit (ex. 500GiB, 2TiB, or 1.5T). A value of 0 corresponds to an Unlimited Quota.",
 			},
 			"enable_deduplication": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     true,
+				ForceNew:    true,
 				Description: "This value corresponds to 'Provisioning Type' in the UI. When set to true, the Provisioning Type will be 'thin provisioning with dedupe'.",
 			},
 			"description": {
@@ -56,14 +74,21 @@ func resourceSilkVolumeGroup() *schema.Resource {
 				Default:     "default_vg_capacity_policy",
 				Description: "The capacity threshold policy profile for the Volume Group.",
 			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a Volume Group with the same name that already exists on the Silk server is taken over instead of being created. Its attributes are updated to match the configuration.",
+			},
 			"timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     15,
+				Deprecated:  "Use the timeouts block and the request_timeout argument of the provider instead. This value is no longer used.",
 				Description: "The number of seconds to wait to establish a connection the Silk server before returning a timeout error.",
 			},
 		},
-	}
+	})
 
 }
 
@@ -71,21 +96,29 @@ func resourceSilkVolumeGroupCreate(ctx context.Context, d *schema.ResourceData,
 
 	// Read in the resource schema arguments for easier assignment
 	name := d.Get("name").(string)
-	quotaInGb := d.Get("quota_in_gb").(int)
+
+	if adopted, diags := adoptExisting(ctx, resourceSilkVolumeGroup(), d, m, "Volume Group", name); adopted {
+		return diags
+	}
+
+	quotaInKiB, err := configuredQuotaInKiB(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	enableDeDuplication := d.Get("enable_deduplication").(bool)
 	description := d.Get("description").(string)
 	capacityPolicy := d.Get("capacity_policy").(string)
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
-	volumeGroup, err := silk.CreateVolumeGroup(name, quotaInGb, enableDeDuplication, description, capacityPolicy, timeout)
+	volumeGroup, err := createVolumeGroup(silk, name, quotaInKiB, enableDeDuplication, description, capacityPolicy, timeout)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	// Set the resource ID
-	d.SetId(fmt.Sprintf("silk-volumeGroup-%d-%s", volumeGroup.ID, strconv.FormatInt(time.Now().Unix(), 10)))
+	d.SetId(strconv.Itoa(volumeGroup.ID))
 
 	return resourceSilkVolumeGroupRead(ctx, d, m)
 }
@@ -95,9 +128,9 @@ func resourceSilkVolumeGroupRead(ctx context.Context, d *schema.ResourceData, m
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
 	// name := d.Get("name").(string)
 
@@ -108,45 +141,25 @@ func resourceSilkVolumeGroupRead(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	for _, volumeGroup := range getVolumeGroup.Hits {
-		if volumeGroup.Name == d.Get("name").(string) {
+		if strconv.Itoa(volumeGroup.ID) == d.Id() {
 
-			// If the Volume Group has a capacity policy, parse the output for the capacity ID and then convert that to the
-			// policy name
+			// If the Volume Group has a capacity policy, convert the capacity policy ref to the policy name
 			if volumeGroup.CapacityPolicy != nil {
-				capacityPolicy := volumeGroup.CapacityPolicy.(map[string]interface{})
-				for _, value := range capacityPolicy {
-					capacityPolicyID, _ := strconv.Atoi(strings.Replace(value.(string), "/vg_capacity_policies/", "", 1))
-					// If an err is returned, we can assume the capacity policy is not present
-					if err != nil {
+				capacityPolicyName, err := volumeGroupCapacityPolicyName(silk, volumeGroup.CapacityPolicy, timeout)
+				if err != nil {
+					if notFound(err) {
 						d.Set("capacity_policy", "")
-
 					}
-
-					capacityPolicyName, err := silk.GetCapacityPolicyName(capacityPolicyID, timeout)
-					if err != nil {
-						if strings.Contains(err.Error(), "The server does not contain") == true {
-							d.Set("capacity_policy", "")
-						}
-						return diag.FromErr(err)
-					}
-
-					d.Set("capacity_policy", capacityPolicyName)
+					return diag.FromErr(err)
 				}
+
+				d.Set("capacity_policy", capacityPolicyName)
 			}
 
 			d.Set("name", volumeGroup.Name)
 			d.Set("obj_id", volumeGroup.ID)
-
-			// When the Volume Group is set to an unlimated quota
-			// the API will return a nil interface which causes
-			// an error to be thrown when trying to convert from
-			//  the usual float64
-			if fmt.Sprintf("%T", volumeGroup.Quota) == "float64" {
-				d.Set("quota_in_gb", volumeGroup.Quota.(float64)/1024/1024)
-			} else {
-				d.Set("quota_in_gb", 0)
-			}
-
+			d.Set("quota_in_gb", volumeGroupQuotaInGB(volumeGroup.Quota))
+			d.Set("quota", formatCapacity(volumeGroupQuotaInKiB(volumeGroup.Quota)))
 			d.Set("enable_deduplication", volumeGroup.IsDedup)
 			d.Set("description", volumeGroup.Description)
 
@@ -163,9 +176,9 @@ func resourceSilkVolumeGroupRead(ctx context.Context, d *schema.ResourceData, m
 
 func resourceSilkVolumeGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	silk := m.(*silksdp.Credentials)
+	silk := m.(*Client).credentials(ctx, d)
 
-	timeout := d.Get("timeout").(int)
+	timeout := requestTimeout(ctx)
 
 	config := map[string]interface{}{}
 	var currentVolumeGroupName string
@@ -180,12 +193,16 @@ func resourceSilkVolumeGroupUpdate(ctx context.Context, d *schema.ResourceData,
 		currentVolumeGroupName = d.Get("name").(string)
 	}
 
-	if d.HasChange("quota_in_gb") {
-		config["quota"] = d.Get("quota_in_gb").(int) * 1024 * 1024
-	}
+	unlock := m.(*Client).lock(volumeGroupLockKey(currentVolumeGroupName), volumeGroupLockKey(d.Get("name").(string)))
+	defer unlock()
+
+	if d.HasChange("quota_in_gb") || d.HasChange("quota") {
+		quotaInKiB, err := configuredQuotaInKiB(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
-	if d.HasChange("enable_deduplication") {
-		return diag.Errorf("enable_deduplication can not be updated after the initial configuration")
+		config["quota"] = quotaInKiB
 	}
 
 	if d.HasChange("description") {
@@ -193,7 +210,13 @@ func resourceSilkVolumeGroupUpdate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if d.HasChange("capacity_policy") {
-		config["capacityPolicy"] = d.Get("capacity_policy").(bool)
+		// The API expects a ref to the capacity policy, so convert the policy name to its ID
+		capacityPolicyID, err := silk.GetCapacityPolicyID(d.Get("capacity_policy").(string), timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		config["capacity_policy"] = map[string]interface{}{"ref": fmt.Sprintf("/vg_capacity_policies/%d", capacityPolicyID)}
 	}
 
 	_, err := silk.UpdateVolumeGroup(currentVolumeGroupName, config, timeout)
@@ -210,10 +233,14 @@ func resourceSilkVolumeGroupDelete(ctx context.Context, d *schema.ResourceData,
 	var diags diag.Diagnostics
 
 	name := d.Get("name").(string)
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
 
-	silk := m.(*silksdp.Credentials)
+	unlock := m.(*Client).lock(volumeGroupLockKey(name))
+	defer unlock()
 
-	_, err := silk.DeleteVolumeGroup(name)
+	_, err := silk.DeleteVolumeGroup(name, timeout)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -224,68 +251,128 @@ func resourceSilkVolumeGroupDelete(ctx context.Context, d *schema.ResourceData,
 }
 func resourceSilkVolumeGroupImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 
-	timeout := d.Get("timeout").(int)
+	d.Set("timeout", 15)
+	d.Set("adopt_existing", false)
+	timeout := requestTimeout(ctx)
 
-	silk := m.(*silksdp.Credentials)
-
-	name := d.Get("name").(string)
+	silk := m.(*Client).credentials(ctx, d)
 
-	getVolumeGroup, err := silk.GetVolumeGroupByName(name, timeout)
+	getVolumeGroup, err := silk.GetVolumeGroups(timeout)
 	if err != nil {
 		return nil, err
 	}
 
+	// Volume Groups can be imported by their SDP ID or by their name
 	for _, volumeGroup := range getVolumeGroup.Hits {
-		if volumeGroup.Name == d.Id() {
+		if importMatches(d.Id(), volumeGroup.ID, volumeGroup.Name) {
 
-			// If the Volume Group has a capacity policy, parse the output for the capacity ID and then convert that to the
-			// policy name
-			if volumeGroup.CapacityPolicy != nil {
-				capacityPolicy := volumeGroup.CapacityPolicy.(map[string]interface{})
-				for _, value := range capacityPolicy {
-					capacityPolicyID, _ := strconv.Atoi(strings.Replace(value.(string), "/vg_capacity_policies/", "", 1))
-					// If an err is returned, we can assume the capacity policy is not present
-					if err != nil {
-						d.Set("capacity_policy", "")
-
-					}
-
-					capacityPolicyName, err := silk.GetCapacityPolicyName(capacityPolicyID, timeout)
-					if err != nil {
-						if strings.Contains(err.Error(), "The server does not contain") == true {
-							d.Set("capacity_policy", "")
-						}
-						return nil, err
-					}
-
-					d.Set("capacity_policy", capacityPolicyName)
-				}
-			} else {
+			// A Volume Group without a capacity policy uses the default policy
+			if volumeGroup.CapacityPolicy == nil {
 				d.Set("capacity_policy", "default_vg_capacity_policy")
 			}
 
-			d.Set("name", volumeGroup.Name)
-			d.Set("obj_id", volumeGroup.ID)
+			d.SetId(strconv.Itoa(volumeGroup.ID))
 
-			// When the Volume Group is set to an unlimated quota
-			// the API will return a nil interface which causes
-			// an error to be thrown when trying to convert from
-			//  the usual float64
-			if fmt.Sprintf("%T", volumeGroup.Quota) == "float64" {
-				d.Set("quota_in_gb", volumeGroup.Quota.(float64)/1024/1024)
-			} else {
-				d.Set("quota_in_gb", 0)
+			diags := resourceSilkVolumeGroupRead(ctx, d, m)
+			if diags.HasError() {
+				return nil, diagsError(diags)
 			}
 
-			d.Set("enable_deduplication", volumeGroup.IsDedup)
-			d.Set("description", volumeGroup.Description)
-			d.Set("timeout", 15)
-			d.SetId(fmt.Sprintf("silk-volumeGroup-%d-%s", volumeGroup.ID, strconv.FormatInt(time.Now().Unix(), 10)))
+			return []*schema.ResourceData{d}, nil
+		}
+	}
 
-			// Stop the loop and return a nil err
+	return nil, notFoundError("The server does not contain a Volume Group with the ID or name '%s'", d.Id())
+}
+
+// volumeGroupCapacityPolicyName parses the capacity policy returned by the API for a Volume Group for the capacity
+// policy ID and then converts that to the policy name.
+func volumeGroupCapacityPolicyName(silk *silksdp.Credentials, capacityPolicy interface{}, timeout int) (string, error) {
+
+	capacityPolicyName := ""
+	for _, value := range capacityPolicy.(map[string]interface{}) {
+		capacityPolicyID, _ := strconv.Atoi(strings.Replace(value.(string), "/vg_capacity_policies/", "", 1))
+
+		name, err := silk.GetCapacityPolicyName(capacityPolicyID, timeout)
+		if err != nil {
+			return "", err
 		}
+
+		capacityPolicyName = name
+	}
+
+	return capacityPolicyName, nil
+}
+
+// volumeGroupQuotaInGB converts the quota returned by the API for a Volume Group to GB. When the Volume Group is set
+// to an unlimited quota the API will return a nil interface which causes an error to be thrown when trying to convert
+// from the usual float64, so 0 is returned instead.
+func volumeGroupQuotaInGB(quota interface{}) int {
+
+	if fmt.Sprintf("%T", quota) == "float64" {
+		return int(quota.(float64) / 1024 / 1024)
 	}
 
-	return []*schema.ResourceData{d}, nil
+	return 0
+}
+
+// volumeGroupQuotaInKiB converts the quota returned by the API for a Volume Group to KiB. Like volumeGroupQuotaInGB,
+// 0 is returned for an unlimited quota.
+func volumeGroupQuotaInKiB(quota interface{}) int {
+
+	if fmt.Sprintf("%T", quota) == "float64" {
+		return int(quota.(float64))
+	}
+
+	return 0
+}
+
+// resourceSilkVolumeGroupCustomizeDiff keeps quota and quota_in_gb in sync. Both are Computed so either one can be
+// configured, which would otherwise keep the current quota when neither is. Like the former default of quota_in_gb,
+// a Volume Group without a quota in the configuration is set back to an Unlimited Quota.
+func resourceSilkVolumeGroupCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	quotaSet := !config.GetAttr("quota").IsNull()
+	quotaInGbSet := !config.GetAttr("quota_in_gb").IsNull()
+
+	switch {
+	case !quotaSet && !quotaInGbSet:
+		if d.Id() == "" || d.Get("quota_in_gb").(int) != 0 || d.Get("quota").(string) != formatCapacity(0) {
+			d.SetNew("quota_in_gb", 0)
+			d.SetNew("quota", formatCapacity(0))
+		}
+	case quotaSet && (d.Id() == "" || d.HasChange("quota")):
+		if !d.NewValueKnown("quota") {
+			return d.SetNewComputed("quota_in_gb")
+		}
+
+		quotaInKiB, err := parseCapacity(d.Get("quota").(string))
+		if err != nil {
+			return err
+		}
+		d.SetNew("quota_in_gb", quotaInKiB/1024/1024)
+	case quotaInGbSet && (d.Id() == "" || d.HasChange("quota_in_gb")):
+		if !d.NewValueKnown("quota_in_gb") {
+			return d.SetNewComputed("quota")
+		}
+
+		d.SetNew("quota", formatCapacity(d.Get("quota_in_gb").(int)*1024*1024))
+	}
+
+	return nil
+}
+
+// configuredQuotaInKiB returns the quota of the Volume Group in KiB from whichever of quota or quota_in_gb is
+// configured. Since both attributes are Computed, the one that changed takes precedence.
+func configuredQuotaInKiB(d *schema.ResourceData) (int, error) {
+	if quota := d.Get("quota").(string); quota != "" && (d.Id() == "" || d.HasChange("quota")) {
+		return parseCapacity(quota)
+	}
 
+	return d.Get("quota_in_gb").(int) * 1024 * 1024, nil
 }
@@ -2,7 +2,8 @@ package silk
 
 import (
 	"fmt"
-	"strings"
+	"io"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -32,10 +33,104 @@ func TestAccSilkVolumeGroup(t *testing.T) {
 					testAccCheckSilkVolumeGroupExists("silk_volume_group.testacc"),
 				),
 			},
+			{
+				Config: testAccCheckSilkVolumeGroupConfigQuota("1.5T"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSilkVolumeGroupExists("silk_volume_group.testacc"),
+					resource.TestCheckResourceAttr("silk_volume_group.testacc", "quota", "1536GiB"),
+					resource.TestCheckResourceAttr("silk_volume_group.testacc", "quota_in_gb", "1536"),
+				),
+			},
+			{
+				// An equivalent spelling of the current quota does not produce a plan
+				Config:   testAccCheckSilkVolumeGroupConfigQuota("1536GiB"),
+				PlanOnly: true,
+			},
+			{
+				// Removing the quota from the configuration sets the Volume Group back to an Unlimited Quota
+				Config: testAccCheckSilkVolumeGroupConfigNoQuota(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSilkVolumeGroupExists("silk_volume_group.testacc"),
+					resource.TestCheckResourceAttr("silk_volume_group.testacc", "quota", "0"),
+					resource.TestCheckResourceAttr("silk_volume_group.testacc", "quota_in_gb", "0"),
+				),
+			},
+			{
+				Config: testAccCheckSilkVolumeGroupConfigCapacityPolicy(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSilkVolumeGroupExists("silk_volume_group.testacc"),
+					resource.TestCheckResourceAttr("silk_volume_group.testacc", "capacity_policy", "TerraformTestAccVolumeGroupCP"),
+				),
+			},
+			{
+				ResourceName:      "silk_volume_group.testacc",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// TestAccSilkVolumeGroupAdopt validates a Volume Group created outside of Terraform is adopted when adopt_existing
+// is set and its description is updated to match the configuration
+func TestAccSilkVolumeGroupAdopt(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSilkVolumeGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testAccCheckSilkCreateVolumeGroupPreConfig("TerraformTestAccVolumeGroupAdopt")
+				},
+				Config: testAccCheckSilkVolumeGroupConfigAdopt("TerraformTestAccVolumeGroupAdopt"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSilkVolumeGroupExists("silk_volume_group.testacc"),
+					resource.TestCheckResourceAttr("silk_volume_group.testacc", "description", "Adopted. Volume used for Terraform silk_volume_group Acceptance Testing"),
+					resource.TestCheckResourceAttr("silk_volume_group.testacc", "quota_in_gb", "10"),
+				),
+			},
 		},
 	})
 }
 
+// TestCreateVolumeGroupCapacityPolicy validates the Volume Group is created with a ref to its capacity policy
+func TestCreateVolumeGroupCapacityPolicy(t *testing.T) {
+	t.Parallel()
+
+	var body string
+	g := testGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/volume_groups":
+			request, _ := io.ReadAll(r.Body)
+			body = string(request)
+			w.Write([]byte(`{"id": 5, "name": "vg1"}`))
+		case r.URL.Path == "/api/v2/vg_capacity_policies":
+			w.Write([]byte(`{"hits": [{"id": 1, "name": "default_vg_capacity_policy"}, {"id": 2, "name": "strict"}], "total": 2}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_msg": "Not Found"}`))
+		}
+	})
+	silk := silksdp.Connect(g.address(), "admin", "secret")
+
+	volumeGroup, err := createVolumeGroup(silk, "vg1", 1048576, true, "test", "strict", 15)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if volumeGroup.ID != 5 {
+		t.Errorf("expected the Volume Group 5, got %d", volumeGroup.ID)
+	}
+
+	expected := `{"capacity_policy":{"ref":"/vg_capacity_policies/2"},"description":"test","is_dedup":true,"name":"vg1","quota":1048576}`
+	if body != expected {
+		t.Errorf("expected the request %s, got %s", expected, body)
+	}
+}
+
 // testAccCheckSilkVolumeGroupConfigBasic returns a fully populated silk_volume_group resource
 func testAccCheckSilkVolumeGroupConfigBasic() string {
 	return fmt.Sprintf(`
@@ -63,6 +158,85 @@ func testAccCheckSilkVolumeGroupConfigUpdate() string {
 
 }
 
+// testAccCheckSilkVolumeGroupConfigQuota sets the quota of the previously updated silk_volume_group resource with
+// a unit string
+func testAccCheckSilkVolumeGroupConfigQuota(quota string) string {
+	return fmt.Sprintf(`
+	resource "silk_volume_group" "testacc" {
+		name = "TerraformTestAccVolumeGroupNew"
+		quota = "%s"
+		enable_deduplication = true
+		description = "Updated. Volume used for Terraform silk_volume_group Acceptance Testing"
+	}
+	`, quota)
+
+}
+
+// testAccCheckSilkVolumeGroupConfigNoQuota returns the previously updated silk_volume_group resource without a quota
+func testAccCheckSilkVolumeGroupConfigNoQuota() string {
+	return fmt.Sprintf(`
+	resource "silk_volume_group" "testacc" {
+		name = "TerraformTestAccVolumeGroupNew"
+		enable_deduplication = true
+		description = "Updated. Volume used for Terraform silk_volume_group Acceptance Testing"
+	}
+	`)
+
+}
+
+// testAccCheckSilkVolumeGroupConfigCapacityPolicy moves the previously updated silk_volume_group resource to a new
+// capacity policy, which is updated in place
+func testAccCheckSilkVolumeGroupConfigCapacityPolicy() string {
+	return fmt.Sprintf(`
+	resource "silk_capacity_policy" "testacc" {
+		name = "TerraformTestAccVolumeGroupCP"
+		warningthreshold = 71
+		errorthreshold = 75
+		criticalthreshold = 90
+		snapshotoverheadthreshold = 30
+	}
+
+	resource "silk_volume_group" "testacc" {
+		name = "TerraformTestAccVolumeGroupNew"
+		quota = "1536GiB"
+		enable_deduplication = true
+		description = "Updated. Volume used for Terraform silk_volume_group Acceptance Testing"
+		capacity_policy = silk_capacity_policy.testacc.name
+	}
+	`)
+
+}
+
+// testAccCheckSilkVolumeGroupConfigAdopt returns a silk_volume_group resource that adopts an existing Volume Group
+func testAccCheckSilkVolumeGroupConfigAdopt(name string) string {
+	return fmt.Sprintf(`
+	resource "silk_volume_group" "testacc" {
+		name = "%s"
+		quota_in_gb = 10
+		enable_deduplication = true
+		description = "Adopted. Volume used for Terraform silk_volume_group Acceptance Testing"
+		adopt_existing = true
+	}
+	`, name)
+
+}
+
+// testAccCheckSilkCreateVolumeGroupPreConfig creates the Volume Group outside of Terraform
+func testAccCheckSilkCreateVolumeGroupPreConfig(name string) error {
+
+	silk, err := silksdp.ConnectEnv()
+	if err != nil {
+		return err
+	}
+
+	_, err = createVolumeGroup(silk, name, 0, true, "Created outside of Terraform", "default_vg_capacity_policy", 15)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // testAccCheckSilkVolumeGroupExists validates the resource was executed successfully
 // by validating it exsits in the Terraform state
 func testAccCheckSilkVolumeGroupExists(n string) resource.TestCheckFunc {
@@ -93,7 +267,7 @@ func testAccCheckSilkVolumeGroupDestroy(s *terraform.State) error {
 	// Validate the Volume Group has been destroyed.
 	_, err = silk.GetVolumeGroupID("TerraformTestAccVolumeUpdated")
 	if err != nil {
-		if strings.Contains(err.Error(), "The server does not contain") {
+		if notFound(err) {
 			return nil
 		}
 		return err
@@ -0,0 +1,225 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// volumeMappingTarget describes the object a mapping resource maps a Volume to. silk_host_volume_mapping and
+// silk_host_group_volume_mapping share their implementation and only differ by their target.
+type volumeMappingTarget struct {
+	attribute  string // host_name or host_group_name
+	collection string // hosts or host_groups
+	objectType string // Host or Host Group
+	lockKey    func(name string) string
+	mapVolume  func(silk *silksdp.Credentials, name, volumeName string, timeout ...int) (*silksdp.CreateHostVolumeMappingResponse, error)
+}
+
+// hostMappingTarget maps a Volume to a Host
+var hostMappingTarget = volumeMappingTarget{
+	attribute:  "host_name",
+	collection: "hosts",
+	objectType: "Host",
+	lockKey:    hostLockKey,
+	mapVolume:  (*silksdp.Credentials).CreateHostVolumeMapping,
+}
+
+// hostGroupMappingTarget maps a Volume to a Host Group
+var hostGroupMappingTarget = volumeMappingTarget{
+	attribute:  "host_group_name",
+	collection: "host_groups",
+	objectType: "Host Group",
+	lockKey:    hostGroupLockKey,
+	mapVolume:  (*silksdp.Credentials).CreateHostGroupVolumeMapping,
+}
+
+// resourceSilkVolumeMapping returns the mapping resource of the provided target
+func resourceSilkVolumeMapping(t volumeMappingTarget) *schema.Resource {
+	return &schema.Resource{
+		CreateContext: t.create,
+		ReadContext:   t.read,
+		UpdateContext: t.update,
+		DeleteContext: t.delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: t.importState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultOperationTimeout),
+			Read:   schema.DefaultTimeout(defaultOperationTimeout),
+			Update: schema.DefaultTimeout(defaultOperationTimeout),
+			Delete: schema.DefaultTimeout(defaultOperationTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"volume_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Volume to map.",
+			},
+			t.attribute: {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: fmt.Sprintf("The name of the %s the Volume is mapped to.", t.objectType),
+			},
+			"obj_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The SDP ID of the mapping.",
+			},
+			"lun": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: t.lunDescription(),
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: fmt.Sprintf("When set to true, a mapping between the Volume and the %s that already exists on the Silk server is taken over instead of being created.", t.objectType),
+			},
+		},
+	}
+
+}
+
+// lunDescription returns the description of the lun attribute
+func (t volumeMappingTarget) lunDescription() string {
+	if t.collection == "host_groups" {
+		return "The LUN the Volume is presented to every Host in the Host Group as."
+	}
+	return "The LUN the Volume is presented to the Host as."
+}
+
+func (t volumeMappingTarget) create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Read in the resource schema arguments for easier assignment
+	volumeName := d.Get("volume_name").(string)
+	name := d.Get(t.attribute).(string)
+
+	if adopted, diags := adoptExisting(ctx, resourceSilkVolumeMapping(t), d, m, "mapping", fmt.Sprintf("%s:%s", volumeName, name)); adopted {
+		return diags
+	}
+
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	unlock := m.(*Client).lock(t.lockKey(name))
+	defer unlock()
+
+	mapping, err := t.mapVolume(silk, name, volumeName, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Set the resource ID
+	d.SetId(strconv.Itoa(mapping.ID))
+
+	return t.read(ctx, d, m)
+}
+
+func (t volumeMappingTarget) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	// The mapping is looked up by its SDP ID. It is also removed along with the Volume.
+	mapping, err := getMapping(silk, d.Id(), timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The ID may have been reused by a mapping of a view or of the other type of target, in which case the mapping
+	// of the resource no longer exists
+	if mapping == nil || !strings.HasPrefix(mapping.Volume.Ref, "/volumes/") || !strings.HasPrefix(mapping.Host.Ref, fmt.Sprintf("/%s/", t.collection)) {
+		// Mapping was not found on the server
+		d.SetId("")
+		return diags
+	}
+
+	volumeName, name, err := volumeMappingNames(silk, *mapping, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("volume_name", volumeName)
+	d.Set(t.attribute, name)
+	d.Set("obj_id", mapping.ID)
+	d.Set("lun", mapping.Lun)
+
+	return diags
+}
+
+// update applies the changes to adopt_existing, which is not sent to the Silk server. Changing any other argument
+// replaces the mapping.
+func (t volumeMappingTarget) update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return t.read(ctx, d, m)
+}
+
+func (t volumeMappingTarget) delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.Errorf("The ID '%s' of the mapping is not an SDP ID", d.Id())
+	}
+
+	unlock := m.(*Client).lock(t.lockKey(d.Get(t.attribute).(string)))
+	defer unlock()
+
+	// The mapping may already have been removed, along with its Volume for example, since it was last refreshed
+	err = deleteMapping(silk, id, timeout)
+	if err != nil && !notFound(err) {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func (t volumeMappingTarget) importState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+
+	d.Set("adopt_existing", false)
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	// Mappings can be imported by their SDP ID or by <volume name>:<host or host group name>
+	volumeName, name, id, err := importMapping(silk, d.Id(), t.collection, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("volume_name", volumeName)
+	d.Set(t.attribute, name)
+	d.SetId(strconv.Itoa(id))
+
+	diags := t.read(ctx, d, m)
+	if diags.HasError() {
+		return nil, diagsError(diags)
+	}
+
+	if d.Id() == "" {
+		return nil, notFoundError("The server does not contain a mapping between the Volume '%s' and the %s '%s'", volumeName, t.objectType, name)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
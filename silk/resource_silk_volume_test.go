@@ -1,8 +1,10 @@
 package silk
 
 import (
+	"context"
 	"fmt"
-	"strings"
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -34,30 +36,321 @@ func TestAccSilkVolume(t *testing.T) {
 				Config: testAccCheckSilkVolumeConfigBasic(volumeName, volumeGroupName, hostNames[0], hostGroupNames[0]),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckSilkVolumeExists("silk_volume.testacc"),
+					resource.TestCheckResourceAttr("silk_volume.testacc", "host_mapping.0.lun", "10"),
+					resource.TestCheckResourceAttrSet("silk_volume.testacc", "host_group_mapping.0.lun"),
 				),
 			},
 			{
 				Config: testAccCheckSilkVolumeConfigAddMapping(volumeName, volumeGroupName, hostNames, hostGroupNames),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckSilkVolumeExists("silk_volume.testacc"),
+					resource.TestCheckResourceAttr("silk_volume.testacc", "host_mapping.0.lun", "11"),
+					resource.TestCheckResourceAttr("silk_volume.testacc", "host_group_mapping.1.lun", "20"),
 				),
 			},
 			{
-				Config: testAccCheckSilkVolumeConfigRemoveMapping(volumeName, volumeGroupName),
+				Config: testAccCheckSilkVolumeConfigRemoveMapping(volumeName, volumeGroupName, "additive"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckSilkVolumeExists("silk_volume.testacc"),
 				),
 			},
 			{
-				Config: testAccCheckSilkVolumeConfigUpdate(volumeName, volumeGroupName),
+				// A mapping made outside of Terraform is ignored in additive mode
+				PreConfig: func() {
+					testAccCheckSilkMapVolumePreConfig(volumeName, hostNames[0])
+				},
+				Config:   testAccCheckSilkVolumeConfigRemoveMapping(volumeName, volumeGroupName, "additive"),
+				PlanOnly: true,
+			},
+			{
+				// and removed in authoritative mode
+				Config: testAccCheckSilkVolumeConfigRemoveMapping(volumeName, volumeGroupName, "authoritative"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSilkVolumeExists("silk_volume.testacc"),
+					resource.TestCheckResourceAttr("silk_volume.testacc", "host_mapping.#", "0"),
+				),
+			},
+			{
+				Config: testAccCheckSilkVolumeConfigUpdate(volumeName, volumeGroupName, "size_in_gb = 20"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckSilkVolumeExists("silk_volume.testacc"),
+					resource.TestCheckResourceAttr("silk_volume.testacc", "size", "20GiB"),
 				),
 			},
+			{
+				// An equivalent spelling of the current size does not produce a plan
+				Config:   testAccCheckSilkVolumeConfigUpdate(volumeName, volumeGroupName, `size = "20480M"`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccCheckSilkVolumeConfigUpdate(volumeName, volumeGroupName, `size = "20.5GiB"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSilkVolumeExists("silk_volume.testacc"),
+					resource.TestCheckResourceAttr("silk_volume.testacc", "size", "20992MiB"),
+					resource.TestCheckResourceAttr("silk_volume.testacc", "size_in_gb", "20"),
+				),
+			},
+			{
+				Config:      testAccCheckSilkVolumeConfigUpdate(volumeName, volumeGroupName, `size = "10GiB"`),
+				ExpectError: regexp.MustCompile("can not be shrunk"),
+			},
+			{
+				// The Volume Group created in the PreCheck has a 50 GB quota
+				Config:      testAccCheckSilkVolumeConfigUpdate(volumeName, volumeGroupName, `size = "60GiB"`),
+				ExpectError: regexp.MustCompile("exceeds the quota"),
+			},
+			{
+				ResourceName:      "silk_volume.testacc",
+				ImportState:       true,
+				ImportStateId:     volumeName,
+				ImportStateVerify: true,
+				// allow_destroy only exists in Terraform and is always false after an import
+				ImportStateVerifyIgnore: []string{"allow_destroy"},
+			},
 		},
 	})
 }
 
+// TestAccSilkVolumeRollback validates that a Volume whose mappings fail to be created is removed when
+// rollback_on_failure is set.
+func TestAccSilkVolumeRollback(t *testing.T) {
+
+	// Required Silk Centric Variables.
+	var volumeName = "TerraformTestAccRollbackVolume"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			silk, err := silksdp.ConnectEnv()
+			if err != nil {
+				return err
+			}
+
+			// Validate the Volume was removed by the rollback
+			_, err = silk.GetVolumeID(volumeName)
+			if err == nil {
+				return fmt.Errorf("The Volume '%s' was not rolled back", volumeName)
+			}
+			if !notFound(err) {
+				return err
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckSilkVolumeConfigRollback(volumeName),
+				ExpectError: regexp.MustCompile("rollback_on_failure is set to true"),
+			},
+		},
+	})
+}
+
+// testAccCheckSilkVolumeConfigRollback returns a silk_volume resource that is mapped to a Host that does not exist
+func testAccCheckSilkVolumeConfigRollback(name string) string {
+	return fmt.Sprintf(`
+	resource "silk_volume_group" "testacc" {
+		name = "TerraformTestAccRollbackVG"
+		quota_in_gb = 20
+		enable_deduplication = true
+		description = "Volume Group used for Terraform silk_volume Acceptance Testing"
+	}
+
+	resource "silk_volume" "testacc" {
+		name = "%s"
+		size_in_gb = 10
+		volume_group_name = silk_volume_group.testacc.name
+		description = "Volume used for Terraform silk_volume Acceptance Testing"
+		host_mapping {
+			name = "TerraformTestAccHostDoesNotExist"
+		}
+		rollback_on_failure = true
+		allow_destroy = true
+	}
+	`, name)
+
+}
+
+func TestVolumeMappingChanges(t *testing.T) {
+
+	testCases := map[string]struct {
+		current          []interface{}
+		new              []interface{}
+		configured       map[string]bool
+		expectedToAdd    []volumeMapping
+		expectedToRemove []string
+	}{
+		"create": {
+			current: nil,
+			new: []interface{}{
+				map[string]interface{}{"name": "host01", "lun": 0},
+				map[string]interface{}{"name": "host02", "lun": 5},
+			},
+			expectedToAdd:    []volumeMapping{{Name: "host01", Lun: unassignedLun}, {Name: "host02", Lun: 5}},
+			expectedToRemove: []string{},
+		},
+		"create with lun 0": {
+			current: nil,
+			new: []interface{}{
+				map[string]interface{}{"name": "host01", "lun": 0},
+				map[string]interface{}{"name": "host02", "lun": 0},
+			},
+			configured:       map[string]bool{"host01": true, "host02": false},
+			expectedToAdd:    []volumeMapping{{Name: "host01", Lun: 0}, {Name: "host02", Lun: unassignedLun}},
+			expectedToRemove: []string{},
+		},
+		"unchanged": {
+			current:          []interface{}{map[string]interface{}{"name": "host01", "lun": 3}},
+			new:              []interface{}{map[string]interface{}{"name": "host01", "lun": 3}},
+			expectedToAdd:    []volumeMapping{},
+			expectedToRemove: []string{},
+		},
+		"lun changed": {
+			current:          []interface{}{map[string]interface{}{"name": "host01", "lun": 3}},
+			new:              []interface{}{map[string]interface{}{"name": "host01", "lun": 7}},
+			expectedToAdd:    []volumeMapping{{Name: "host01", Lun: 7}},
+			expectedToRemove: []string{"host01"},
+		},
+		"lun changed to 0": {
+			current:          []interface{}{map[string]interface{}{"name": "host01", "lun": 3}},
+			new:              []interface{}{map[string]interface{}{"name": "host01", "lun": 0}},
+			configured:       map[string]bool{"host01": true},
+			expectedToAdd:    []volumeMapping{{Name: "host01", Lun: 0}},
+			expectedToRemove: []string{"host01"},
+		},
+		"remove first mapping": {
+			current: []interface{}{
+				map[string]interface{}{"name": "host01", "lun": 1},
+				map[string]interface{}{"name": "host02", "lun": 2},
+			},
+			// host02 inherits the LUN of host01 from the state
+			new:              []interface{}{map[string]interface{}{"name": "host02", "lun": 1}},
+			configured:       map[string]bool{"host02": false},
+			expectedToAdd:    []volumeMapping{},
+			expectedToRemove: []string{"host01"},
+		},
+		"remove first mapping and request its lun": {
+			current: []interface{}{
+				map[string]interface{}{"name": "host01", "lun": 1},
+				map[string]interface{}{"name": "host02", "lun": 2},
+			},
+			new:              []interface{}{map[string]interface{}{"name": "host02", "lun": 1}},
+			configured:       map[string]bool{"host02": true},
+			expectedToAdd:    []volumeMapping{{Name: "host02", Lun: 1}},
+			expectedToRemove: []string{"host02", "host01"},
+		},
+		"add mapping at the position of another": {
+			current: []interface{}{
+				map[string]interface{}{"name": "host01", "lun": 1},
+			},
+			// host02 inherits the LUN of host01 from the state
+			new:              []interface{}{map[string]interface{}{"name": "host02", "lun": 1}, map[string]interface{}{"name": "host01", "lun": 0}},
+			configured:       map[string]bool{"host01": false, "host02": false},
+			expectedToAdd:    []volumeMapping{{Name: "host02", Lun: unassignedLun}},
+			expectedToRemove: []string{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		toAdd, toRemove := volumeMappingChanges(testCase.current, testCase.new, testCase.configured)
+
+		if !reflect.DeepEqual(toAdd, testCase.expectedToAdd) {
+			t.Fatalf("%s: expected to add %v, got %v", name, testCase.expectedToAdd, toAdd)
+		}
+
+		if !reflect.DeepEqual(toRemove, testCase.expectedToRemove) {
+			t.Fatalf("%s: expected to remove %v, got %v", name, testCase.expectedToRemove, toRemove)
+		}
+	}
+}
+
+// TestRefreshedVolumeMappingsPlan validates a configuration whose Hosts are not sorted by name, with a LUN that is only
+// set on one of them, does not produce a plan once the mappings, which the server returns sorted by name, are refreshed
+func TestRefreshedVolumeMappingsPlan(t *testing.T) {
+
+	r := resourceSilkVolume()
+
+	config := map[string]interface{}{
+		"name":              "vol1",
+		"size_in_gb":        10,
+		"volume_group_name": "vg1",
+		"description":       "test",
+		"host_mapping": []interface{}{
+			map[string]interface{}{"name": "host2"},
+			map[string]interface{}{"name": "host1", "lun": 3},
+		},
+	}
+
+	// The state after the Volume was created with the configuration
+	state := []interface{}{
+		map[string]interface{}{"name": "host2", "lun": 4},
+		map[string]interface{}{"name": "host1", "lun": 3},
+	}
+	server := []interface{}{
+		map[string]interface{}{"name": "host1", "lun": 3},
+		map[string]interface{}{"name": "host2", "lun": 4},
+	}
+
+	// The refreshed state, with the defaults of the arguments that are not configured
+	d := r.Data(nil)
+	d.SetId("1")
+	for key, value := range config {
+		d.Set(key, value)
+	}
+	for _, key := range []string{"vmware", "read_only", "allow_destroy", "allow_shrink", "rollback_on_failure", "adopt_existing"} {
+		d.Set(key, false)
+	}
+	d.Set("timeout", 15)
+	d.Set("obj_id", 1)
+	d.Set("volume_group_id", 1)
+	d.Set("scsi_sn", "sn")
+	d.Set("size", "10GiB")
+	d.Set("host_mapping", refreshedVolumeMappings(listMappingMode("", state), state, server))
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !diff.Empty() {
+		t.Errorf("expected an empty plan, got %v", diff)
+	}
+}
+
+func TestOrderedVolumeMappings(t *testing.T) {
+
+	block := func(name string, lun int) interface{} {
+		return map[string]interface{}{"name": name, "lun": lun}
+	}
+
+	testCases := map[string]struct {
+		state    []interface{}
+		server   []interface{}
+		expected []interface{}
+	}{
+		"state order with the server LUNs": {
+			state:    []interface{}{block("host02", 0), block("host01", 0)},
+			server:   []interface{}{block("host01", 0), block("host02", 5)},
+			expected: []interface{}{block("host02", 5), block("host01", 0)},
+		},
+		"added on the server": {
+			state:    []interface{}{block("host02", 1)},
+			server:   []interface{}{block("host03", 2), block("host02", 1)},
+			expected: []interface{}{block("host02", 1), block("host03", 2)},
+		},
+	}
+
+	for name, testCase := range testCases {
+		ordered := orderedVolumeMappings(testCase.state, testCase.server)
+		if !reflect.DeepEqual(ordered, testCase.expected) {
+			t.Fatalf("%s: expected %v, got %v", name, testCase.expected, ordered)
+		}
+	}
+}
+
 // testAccCheckSilkCreateVolumeGroupPreCheck creates the Volume Group required for
 // the full acceptance test
 func testAccCheckSilkCreateVolumeGroupPreCheck(volumeGroupName string) error {
@@ -125,6 +418,22 @@ func testAccCheckSilkCreateHostGroupsPreCheck(hostGroupNames []string) error {
 	return nil
 }
 
+// testAccCheckSilkMapVolumePreConfig maps the Volume to the Host outside of Terraform
+func testAccCheckSilkMapVolumePreConfig(volumeName, hostName string) error {
+
+	silk, err := silksdp.ConnectEnv()
+	if err != nil {
+		return err
+	}
+
+	_, err = silk.CreateHostVolumeMapping(hostName, volumeName)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // testAccCheckSilkVolumeConfigBasic returns a fully populated silk_volume resource
 func testAccCheckSilkVolumeConfigBasic(name, volumeGroupName, hostName, hostGroupName string) string {
 	return fmt.Sprintf(`
@@ -135,8 +444,13 @@ func testAccCheckSilkVolumeConfigBasic(name, volumeGroupName, hostName, hostGrou
 		vmware = true
 		description = "Volume used for Terraform silk_volume Acceptance Testing"
 		read_only = false
-		host_mapping = ["%s"]
-		host_group_mapping = ["%s"]
+		host_mapping {
+			name = "%s"
+			lun = 10
+		}
+		host_group_mapping {
+			name = "%s"
+		}
 		allow_destroy = true
 	}
 	`, name, volumeGroupName, hostName, hostGroupName)
@@ -154,8 +468,20 @@ func testAccCheckSilkVolumeConfigAddMapping(name, volumeGroupName string, hostNa
 		vmware = true
 		description = "Volume used for Terraform silk_volume Acceptance Testing"
 		read_only = false
-		host_mapping = ["%s", "%s"]
-		host_group_mapping = ["%s", "%s"]
+		host_mapping {
+			name = "%s"
+			lun = 11
+		}
+		host_mapping {
+			name = "%s"
+		}
+		host_group_mapping {
+			name = "%s"
+		}
+		host_group_mapping {
+			name = "%s"
+			lun = 20
+		}
 		allow_destroy = true
 	}
 	`, name, volumeGroupName, hostNames[0], hostNames[1], hostGroupNames[0], hostGroupNames[1])
@@ -165,7 +491,7 @@ func testAccCheckSilkVolumeConfigAddMapping(name, volumeGroupName string, hostNa
 // testAccCheckSilkVolumeConfigAddMapping removes all of the host and host_groups to the previously created
 // silk_volume resource. This validates the remove functionality while at the same time preparing the resource for
 // terraform destroy (i.e you can't destroy the volume when it has mapping)
-func testAccCheckSilkVolumeConfigRemoveMapping(name, volumeGroupName string) string {
+func testAccCheckSilkVolumeConfigRemoveMapping(name, volumeGroupName, mappingMode string) string {
 	return fmt.Sprintf(`
 	resource "silk_volume" "testacc" {
 		name = "%s"
@@ -174,30 +500,27 @@ func testAccCheckSilkVolumeConfigRemoveMapping(name, volumeGroupName string) str
 		vmware = true
 		description = "Volume used for Terraform silk_volume Acceptance Testing"
 		read_only = false
-		host_mapping = []
-		host_group_mapping = []
+		mapping_mode = "%s"
 		allow_destroy = true
 	}
-	`, name, volumeGroupName)
+	`, name, volumeGroupName, mappingMode)
 
 }
 
-// testAccCheckSilkVolumeConfigUpdate modifies the size_in_gb, description, and read_only
-// paramaters to validated Update functionality
-func testAccCheckSilkVolumeConfigUpdate(name, volumeGroupName string) string {
+// testAccCheckSilkVolumeConfigUpdate modifies the size, description, and read_only
+// paramaters to validated Update functionality. The size is set with the provided size_in_gb or size argument.
+func testAccCheckSilkVolumeConfigUpdate(name, volumeGroupName, sizeArgument string) string {
 	return fmt.Sprintf(`
 	resource "silk_volume" "testacc" {
 		name = "%s"
-		size_in_gb = 20
+		%s
 		volume_group_name = "%s"
 		vmware = true
 		description = "Updated. Volume used for Terraform silk_volume Acceptance Testing"
 		read_only = true
-		host_mapping = []
-		host_group_mapping = []
 		allow_destroy = true
 	}
-	`, name, volumeGroupName)
+	`, name, sizeArgument, volumeGroupName)
 
 }
 
@@ -259,7 +582,7 @@ func testAccCheckSilkVolumeDestroy(s *terraform.State) error {
 	// Validate the Volume has been destroyed
 	_, err = silk.GetVolumeID(volumeName)
 	if err != nil {
-		if strings.Contains(err.Error(), "The server does not contain") {
+		if notFound(err) {
 			return nil
 		}
 		return err
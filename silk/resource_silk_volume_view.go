@@ -0,0 +1,505 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+func resourceSilkVolumeView() *schema.Resource {
+	return withIDStateUpgrader(&schema.Resource{
+		CreateContext: resourceSilkVolumeViewCreate,
+		ReadContext:   resourceSilkVolumeViewRead,
+		UpdateContext: resourceSilkVolumeViewUpdate,
+		DeleteContext: resourceSilkVolumeViewDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSilkVolumeViewImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultOperationTimeout),
+			Read:   schema.DefaultTimeout(defaultOperationTimeout),
+			Update: schema.DefaultTimeout(defaultOperationTimeout),
+			Delete: schema.DefaultTimeout(defaultOperationTimeout),
+		},
+		SchemaVersion: 1,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the View.",
+			},
+			"obj_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The SDP ID of the View.",
+			},
+			"full_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The full SDP name of the View, in the form of '<volume group name>:<view name>'.",
+			},
+			"snapshot_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"snapshot_id", "snapshot_name"},
+				Description:  "The SDP ID of the Snapshot the View is created from.",
+			},
+			"snapshot_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"snapshot_id", "snapshot_name"},
+				Description:  "The full SDP name, in the form of '<volume group name>:<snapshot name>', of the Snapshot the View is created from.",
+			},
+			"retention_policy_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Retention Policy the View is retained under.",
+			},
+			"volume_group_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the Volume Group the View belongs to.",
+			},
+			"creation_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time, in RFC 3339 format, the View was created.",
+			},
+			"allow_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "The View can only be destroyed through Terraform when set to true.",
+			},
+			"rollback_on_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a View whose mappings fail to be created is removed, along with the mappings that were created, instead of being kept in the state as tainted.",
+			},
+			"host_mapping":       volumeMappingSchema("View", "Host"),
+			"host_group_mapping": volumeMappingSchema("View", "Host Group"),
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When set to true, a View with the same name that already exists on the Silk server is taken over instead of being created. Its attributes are updated to match the configuration.",
+			},
+		},
+	})
+
+}
+
+func resourceSilkVolumeViewCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Read in the resource schema arguments for easier assignment
+	name := d.Get("name").(string)
+	snapshotID := d.Get("snapshot_id").(int)
+	snapshotName := d.Get("snapshot_name").(string)
+	retentionPolicyName := d.Get("retention_policy_name").(string)
+	hostMapping := d.Get("host_mapping").([]interface{})
+	hostGroupMapping := d.Get("host_group_mapping").([]interface{})
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	getSnapshot, err := getSnapshots(silk, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// When the Snapshot is referenced by name, look up its ID
+	if snapshotName != "" {
+		for _, snapshot := range getSnapshot.Hits {
+			if snapshot.Name == snapshotName && !snapshot.IsDeleted && !snapshot.isView() {
+				snapshotID = snapshot.ID
+			}
+		}
+
+		if snapshotID == 0 {
+			return diag.Errorf("The server does not contain a Snapshot named '%s'", snapshotName)
+		}
+	}
+
+	// Views are named after the Volume Group of their Snapshot on the server, in the form of
+	// <volume group name>:<view name>
+	volumeGroupName := ""
+	for _, snapshot := range getSnapshot.Hits {
+		if snapshot.ID != snapshotID {
+			continue
+		}
+
+		// The SDP only creates Views from Snapshots that are exposable
+		if !snapshot.IsExposable {
+			return diag.Errorf("The Snapshot '%s' is not exposable. Set exposable to true on the Snapshot to create a View from it.", snapshot.Name)
+		}
+
+		volumeGroupName = strings.SplitN(snapshot.Name, ":", 2)[0]
+	}
+
+	if d.Get("adopt_existing").(bool) {
+		if adopted, diags := adoptExisting(ctx, resourceSilkVolumeView(), d, m, "View", fmt.Sprintf("%s:%s", volumeGroupName, name)); adopted {
+			return diags
+		}
+	}
+
+	unlock := m.(*Client).lock(mappingLockKeys(d)...)
+	defer unlock()
+
+	retentionPolicyID, err := silk.GetRetentionPolicyID(retentionPolicyName, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	view, err := createView(silk, name, snapshotID, retentionPolicyID, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Save the ID immediately so the View is tracked even when one of the mapping steps below fails
+	d.SetId(strconv.Itoa(view.ID))
+	d.Set("obj_id", view.ID)
+
+	viewRef := fmt.Sprintf("/snapshots/%d", view.ID)
+
+	// Track the mappings that have been created so a failure only records, or rolls back, those
+	createdHostMappings := []interface{}{}
+	createdHostGroupMappings := []interface{}{}
+	createdMappingIDs := []int{}
+	mappingFailed := func(err error) diag.Diagnostics {
+		d.Set("host_mapping", createdHostMappings)
+		d.Set("host_group_mapping", createdHostGroupMappings)
+
+		return createFailed(d, "View", func() error {
+			return rollbackVolumeView(silk, view.ID, createdMappingIDs, timeout)
+		}, err)
+	}
+
+	// Map each Host to the View, using the requested LUN when one is provided
+	hostMappingToAdd, _ := volumeMappingChanges(nil, hostMapping, configuredLuns(d, "host_mapping"))
+	for _, h := range hostMappingToAdd {
+		ref, err := hostRef(silk, h.Name, timeout)
+		if err != nil {
+			return mappingFailed(err)
+		}
+
+		mapping, err := createMapping(silk, ref, viewRef, h.Lun, timeout)
+		if err != nil {
+			return mappingFailed(err)
+		}
+
+		createdHostMappings = append(createdHostMappings, map[string]interface{}{"name": h.Name, "lun": mapping.Lun})
+		createdMappingIDs = append(createdMappingIDs, mapping.ID)
+	}
+
+	// Map each Host Group to the View, using the requested LUN when one is provided
+	hostGroupMappingToAdd, _ := volumeMappingChanges(nil, hostGroupMapping, configuredLuns(d, "host_group_mapping"))
+	for _, hg := range hostGroupMappingToAdd {
+		ref, err := hostGroupRef(silk, hg.Name, timeout)
+		if err != nil {
+			return mappingFailed(err)
+		}
+
+		mapping, err := createMapping(silk, ref, viewRef, hg.Lun, timeout)
+		if err != nil {
+			return mappingFailed(err)
+		}
+
+		createdHostGroupMappings = append(createdHostGroupMappings, map[string]interface{}{"name": hg.Name, "lun": mapping.Lun})
+		createdMappingIDs = append(createdMappingIDs, mapping.ID)
+	}
+
+	return resourceSilkVolumeViewRead(ctx, d, m)
+}
+
+func resourceSilkVolumeViewRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	getSnapshot, err := getSnapshots(silk, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, view := range getSnapshot.Hits {
+		if strconv.Itoa(view.ID) == d.Id() && !view.IsDeleted {
+
+			err := resourceSilkVolumeViewSetData(d, silk, view, getSnapshot.Hits, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			// Stop the loop and return a nil err
+			return diags
+		}
+	}
+	// View was not found on the server
+	d.SetId("")
+
+	return diags
+
+}
+
+func resourceSilkVolumeViewUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	timeout := requestTimeout(ctx)
+
+	viewRef := fmt.Sprintf("/snapshots/%d", d.Get("obj_id").(int))
+
+	unlock := m.(*Client).lock(mappingLockKeys(d)...)
+	defer unlock()
+
+	if d.HasChanges("host_mapping", "host_group_mapping") {
+
+		mappings, err := getMappings(silk, viewRef, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// Remove the mappings of Hosts that are no longer configured, or whose LUN changed, then map each new Host to
+		// the View
+		c, n := d.GetChange("host_mapping")
+		hostMappingToAdd, hostMappingToRemove := volumeMappingChanges(c.([]interface{}), n.([]interface{}), configuredLuns(d, "host_mapping"))
+		for _, h := range hostMappingToRemove {
+			ref, err := hostRef(silk, h, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			for _, mapping := range mappings {
+				if mapping.Host.Ref == ref {
+					err := deleteMapping(silk, mapping.ID, timeout)
+					if err != nil {
+						return diag.FromErr(err)
+					}
+				}
+			}
+		}
+
+		for _, h := range hostMappingToAdd {
+			ref, err := hostRef(silk, h.Name, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			_, err = createMapping(silk, ref, viewRef, h.Lun, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		// Remove the mappings of Host Groups that are no longer configured, or whose LUN changed, then map each new
+		// Host Group to the View
+		c, n = d.GetChange("host_group_mapping")
+		hostGroupMappingToAdd, hostGroupMappingToRemove := volumeMappingChanges(c.([]interface{}), n.([]interface{}), configuredLuns(d, "host_group_mapping"))
+		for _, hg := range hostGroupMappingToRemove {
+			ref, err := hostGroupRef(silk, hg, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			for _, mapping := range mappings {
+				if mapping.Host.Ref == ref {
+					err := deleteMapping(silk, mapping.ID, timeout)
+					if err != nil {
+						return diag.FromErr(err)
+					}
+				}
+			}
+		}
+
+		for _, hg := range hostGroupMappingToAdd {
+			ref, err := hostGroupRef(silk, hg.Name, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			_, err = createMapping(silk, ref, viewRef, hg.Lun, timeout)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return resourceSilkVolumeViewRead(ctx, d, m)
+}
+
+func resourceSilkVolumeViewDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	if d.Get("allow_destroy") == false {
+		return diag.Errorf("The `allow_destroy` value is set to false. The view can not be destroyed through Terraform")
+	}
+
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	unlock := m.(*Client).lock(mappingLockKeys(d)...)
+	defer unlock()
+
+	// Remove every mapping before removing the view
+	mappings, err := getMappings(silk, fmt.Sprintf("/snapshots/%d", d.Get("obj_id").(int)), timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, mapping := range mappings {
+		err := deleteMapping(silk, mapping.ID, timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	err = deleteSnapshot(silk, d.Get("obj_id").(int), timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func resourceSilkVolumeViewImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+
+	d.Set("adopt_existing", false)
+	timeout := requestTimeout(ctx)
+
+	silk := m.(*Client).credentials(ctx, d)
+
+	getSnapshot, err := getSnapshots(silk, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Views can be imported by their SDP ID or by their full SDP name, in the form of <volume group name>:<view name>
+	for _, view := range getSnapshot.Hits {
+		if importMatches(d.Id(), view.ID, view.Name) && !view.IsDeleted && view.isView() {
+
+			err := resourceSilkVolumeViewSetData(d, silk, view, getSnapshot.Hits, timeout)
+			if err != nil {
+				return nil, err
+			}
+
+			d.Set("allow_destroy", false)
+			d.Set("rollback_on_failure", false)
+			d.SetId(strconv.Itoa(view.ID))
+
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, notFoundError("The server does not contain a View with the ID or name '%s'", d.Id())
+}
+
+// rollbackVolumeView removes the provided mappings and then the View itself after a failed create.
+func rollbackVolumeView(silk *silksdp.Credentials, viewID int, mappingIDs []int, timeout int) error {
+
+	for _, mappingID := range mappingIDs {
+		err := deleteMapping(silk, mappingID, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return deleteSnapshot(silk, viewID, timeout)
+}
+
+// resourceSilkVolumeViewSetData populates the Terraform state from the provided view. The snapshots slice is used to
+// convert the source ref of the view back to the name of the Snapshot it was created from.
+func resourceSilkVolumeViewSetData(d *schema.ResourceData, silk *silksdp.Credentials, view snapshot, snapshots []snapshot, timeout int) error {
+
+	snapshotID, err := refID(view.Source.Ref, "snapshots")
+	if err != nil {
+		return err
+	}
+
+	// snapshot_id and snapshot_name are mutually exclusive so only the one used in the configuration is kept in sync.
+	// Imported views use the Snapshot name.
+	for _, snapshot := range snapshots {
+		if snapshot.ID == snapshotID {
+			if _, ok := d.GetOk("snapshot_id"); ok {
+				d.Set("snapshot_id", snapshot.ID)
+			} else {
+				d.Set("snapshot_name", snapshot.Name)
+			}
+		}
+	}
+
+	volumeGroupID, err := refID(view.VolumeGroup.Ref, "volume_groups")
+	if err != nil {
+		return err
+	}
+
+	getVolumeGroups, err := silk.GetVolumeGroups(timeout)
+	if err != nil {
+		return err
+	}
+
+	for _, volumeGroup := range getVolumeGroups.Hits {
+		if volumeGroup.ID == volumeGroupID {
+			d.Set("volume_group_name", volumeGroup.Name)
+		}
+	}
+
+	retentionPolicyID, err := refID(view.RetentionPolicy.Ref, "retention_policies")
+	if err != nil {
+		return err
+	}
+
+	getRetentionPolicy, err := silk.GetRetentionPolicy(timeout)
+	if err != nil {
+		return err
+	}
+
+	for _, retentionPolicy := range getRetentionPolicy.Hits {
+		if retentionPolicy.ID == retentionPolicyID {
+			d.Set("retention_policy_name", retentionPolicy.Name)
+		}
+	}
+
+	// Get the current Hosts and Host Groups mapped to the View, along with the LUN of each mapping, then set the TF
+	// host_mapping and host_group_mapping values with those responses
+	mappings, err := getMappings(silk, fmt.Sprintf("/snapshots/%d", view.ID), timeout)
+	if err != nil {
+		return err
+	}
+
+	hostMappings, hostGroupMappings, err := flattenMappings(silk, mappings, timeout)
+	if err != nil {
+		return err
+	}
+
+	// Keep the order of the configuration to prevent any TF comparison issues
+	d.Set("host_mapping", orderedVolumeMappings(d.Get("host_mapping").([]interface{}), hostMappings))
+	d.Set("host_group_mapping", orderedVolumeMappings(d.Get("host_group_mapping").([]interface{}), hostGroupMappings))
+
+	d.Set("name", view.ShortName)
+	d.Set("obj_id", view.ID)
+	d.Set("full_name", view.Name)
+	d.Set("creation_time", time.Unix(int64(view.CreationTime), 0).UTC().Format(time.RFC3339))
+
+	return nil
+}
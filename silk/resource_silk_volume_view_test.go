@@ -0,0 +1,178 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/silk-us/silk-sdp-go-sdk/silksdp"
+)
+
+// TestAccSilkVolumeView is the main function that is executed during the test process.
+func TestAccSilkVolumeView(t *testing.T) {
+
+	// Required Silk Centric Variables.
+	var viewName = "TerraformTestAccView"
+	var hostName = "TerraformTestAccViewHost"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSilkVolumeViewDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSilkVolumeViewConfigBasic(viewName, hostName, fmt.Sprintf(`["%s"]`, hostName)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSilkVolumeViewExists("silk_volume_view.testacc"),
+					resource.TestCheckResourceAttr("silk_volume_view.testacc", "host_mapping.#", "1"),
+					resource.TestCheckResourceAttr("silk_volume_view.testacc", "volume_group_name", "TerraformTestAccViewVG"),
+				),
+			},
+			{
+				Config: testAccCheckSilkVolumeViewConfigBasic(viewName, hostName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSilkVolumeViewExists("silk_volume_view.testacc"),
+					resource.TestCheckResourceAttr("silk_volume_view.testacc", "host_mapping.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// TestVolumeViewCreateNotExposable validates a View is not created from a Snapshot that is not exposable
+func TestVolumeViewCreateNotExposable(t *testing.T) {
+	t.Parallel()
+
+	g := testGateway(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/snapshots":
+			w.Write([]byte(`{"hits": [{"id": 3, "name": "vg1:nightly", "is_exposable": false, "source": {"ref": "/volume_groups/1"}}], "total": 1}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error_msg": "Not Found"}`))
+		}
+	})
+	client := &Client{Credentials: silksdp.Connect(g.address(), "admin", "secret"), gateway: g, locks: newMutexKV()}
+
+	r := resourceSilkVolumeView()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                  "view1",
+		"snapshot_name":         "vg1:nightly",
+		"retention_policy_name": "Backup",
+	})
+
+	diags := r.CreateContext(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "is not exposable") {
+		t.Errorf("expected the Snapshot to not be exposable, got %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected no View to be created, got the ID '%s'", d.Id())
+	}
+}
+
+// testAccCheckSilkVolumeViewConfigBasic returns a silk_volume_view resource along with the Volume Group, Volume,
+// Retention Policy, Snapshot, and Host it depends on
+func testAccCheckSilkVolumeViewConfigBasic(name, hostName, hostMapping string) string {
+	return fmt.Sprintf(`
+	resource "silk_volume_group" "testacc" {
+		name = "TerraformTestAccViewVG"
+		quota_in_gb = 20
+		enable_deduplication = true
+		description = "Volume Group used for Terraform silk_volume_view Acceptance Testing"
+	}
+
+	resource "silk_volume" "testacc" {
+		name = "TerraformTestAccViewVolume"
+		size_in_gb = 10
+		volume_group_name = silk_volume_group.testacc.name
+		description = "Volume used for Terraform silk_volume_view Acceptance Testing"
+		allow_destroy = true
+	}
+
+	resource "silk_retention_policy" "testacc" {
+		name = "TerraformTestAccViewRP"
+		num_snapshots = "5"
+		weeks = "0"
+		days = "1"
+		hours = "0"
+	}
+
+	resource "silk_snapshot" "testacc" {
+		name = "TerraformTestAccViewSnapshot"
+		volume_group_name = silk_volume.testacc.volume_group_name
+		retention_policy_name = silk_retention_policy.testacc.name
+		exposable = true
+	}
+
+	resource "silk_host" "testacc" {
+		name = "%s"
+		host_type = "Linux"
+	}
+
+	resource "silk_volume_view" "testacc" {
+		name = "%s"
+		snapshot_id = silk_snapshot.testacc.obj_id
+		retention_policy_name = silk_retention_policy.testacc.name
+		%s
+		allow_destroy = true
+
+		depends_on = [silk_host.testacc]
+	}
+	`, hostName, name, hostMapping)
+
+}
+
+// testAccCheckSilkVolumeViewExists validates the resource was executed successfully
+// by validating it exsits in the Terraform state
+func testAccCheckSilkVolumeViewExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resources, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if resources.Primary.ID == "" {
+			return fmt.Errorf("No View set")
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckSilkVolumeViewDestroy verifies the View was sussuccessfully destroyed
+// by the terraform destroy process
+func testAccCheckSilkVolumeViewDestroy(s *terraform.State) error {
+
+	silk, err := silksdp.ConnectEnv()
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := getSnapshots(silk, 15)
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "silk_volume_view" {
+			continue
+		}
+
+		for _, view := range snapshots.Hits {
+			if fmt.Sprintf("%d", view.ID) == rs.Primary.Attributes["obj_id"] && !view.IsDeleted {
+				return fmt.Errorf("The View '%s' still exists on the server", view.Name)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package silk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Version 0 of every resource used "silk-<type>-<SDP ID>-<unix timestamp>" (ex. silk-volume-12-1600000000) as the
+// resource ID. Since the timestamp changed on every create and import, the ID could not be used to look the object
+// up and the resources were read by name instead. Version 1 uses the SDP ID of the object as the resource ID.
+
+// withIDStateUpgrader adds the version 0 to version 1 StateUpgrader to the provided resource. Only the resource ID
+// changed between the two versions so the version 0 state has the same type as the current schema.
+func withIDStateUpgrader(resource *schema.Resource) *schema.Resource {
+	resource.StateUpgraders = []schema.StateUpgrader{idStateUpgrader(resource)}
+
+	return resource
+}
+
+// idStateUpgrader returns the version 0 to version 1 StateUpgrader of a resource whose version 1 schema is v1.
+func idStateUpgrader(v1 *schema.Resource) schema.StateUpgrader {
+	return schema.StateUpgrader{
+		Version: 0,
+		Type:    v1.CoreConfigSchema().ImpliedType(),
+		Upgrade: resourceSilkIDStateUpgradeV0,
+	}
+}
+
+// resourceSilkIDStateUpgradeV0 rewrites the version 0 resource ID to the SDP ID of the object. When the ID can not
+// be parsed, the obj_id value stored in the state is used instead.
+func resourceSilkIDStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+
+	id, _ := rawState["id"].(string)
+
+	// The SDP ID is always the second to last section of the version 0 ID
+	sections := strings.Split(id, "-")
+	if len(sections) >= 3 {
+		if objID, err := strconv.Atoi(sections[len(sections)-2]); err == nil {
+			rawState["id"] = strconv.Itoa(objID)
+			return rawState, nil
+		}
+	}
+
+	// JSON numbers are decoded as float64
+	if objID, ok := rawState["obj_id"].(float64); ok && objID != 0 {
+		rawState["id"] = strconv.Itoa(int(objID))
+		return rawState, nil
+	}
+
+	return nil, fmt.Errorf("Unable to determine the SDP ID from the resource ID '%s'", id)
+}
+
+// Version 1 of silk_volume stored host_mapping and host_group_mapping as lists of names. Version 2 stores them as
+// blocks holding the name and the LUN of each mapping.
+
+// resourceSilkVolumeStateUpgraders returns every StateUpgrader of the provided silk_volume resource.
+func resourceSilkVolumeStateUpgraders(resource *schema.Resource) []schema.StateUpgrader {
+	v1 := resourceSilkVolumeV1(resource)
+
+	return []schema.StateUpgrader{
+		idStateUpgrader(v1),
+		{
+			Version: 1,
+			Type:    v1.CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceSilkVolumeStateUpgradeV1,
+		},
+	}
+}
+
+// resourceSilkVolumeV1 returns the version 1 schema of the provided silk_volume resource.
+func resourceSilkVolumeV1(resource *schema.Resource) *schema.Resource {
+	v1 := map[string]*schema.Schema{}
+	for key, value := range resource.Schema {
+		v1[key] = value
+	}
+
+	for _, key := range []string{"host_mapping", "host_group_mapping"} {
+		v1[key] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+
+	return &schema.Resource{Schema: v1}
+}
+
+// resourceSilkVolumeStateUpgradeV1 converts each mapped name into a mapping block. The LUN is populated by the next
+// refresh.
+func resourceSilkVolumeStateUpgradeV1(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+
+	for _, key := range []string{"host_mapping", "host_group_mapping"} {
+		names, _ := rawState[key].([]interface{})
+
+		mappings := []interface{}{}
+		for _, name := range names {
+			mappings = append(mappings, map[string]interface{}{"name": name, "lun": 0})
+		}
+
+		rawState[key] = mappings
+	}
+
+	return rawState, nil
+}
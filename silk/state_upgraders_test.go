@@ -0,0 +1,76 @@
+package silk
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestResourceSilkIDStateUpgradeV0(t *testing.T) {
+
+	testCases := map[string]struct {
+		rawState   map[string]interface{}
+		expectedID string
+	}{
+		"volume": {
+			rawState:   map[string]interface{}{"id": "silk-volume-12-1600000000", "obj_id": float64(12)},
+			expectedID: "12",
+		},
+		"volume group": {
+			rawState:   map[string]interface{}{"id": "silk-volumeGroup-3-1600000000"},
+			expectedID: "3",
+		},
+		"host group": {
+			rawState:   map[string]interface{}{"id": "silk-host-group-7-1600000000"},
+			expectedID: "7",
+		},
+		"capacity policy": {
+			rawState:   map[string]interface{}{"id": "silk-CapacityPolicy-21-1600000000"},
+			expectedID: "21",
+		},
+		"obj_id fallback": {
+			rawState:   map[string]interface{}{"id": "unexpected", "obj_id": float64(5)},
+			expectedID: "5",
+		},
+	}
+
+	for name, testCase := range testCases {
+		upgraded, err := resourceSilkIDStateUpgradeV0(context.Background(), testCase.rawState, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+
+		if upgraded["id"] != testCase.expectedID {
+			t.Fatalf("%s: expected ID '%s', got '%s'", name, testCase.expectedID, upgraded["id"])
+		}
+	}
+
+	if _, err := resourceSilkIDStateUpgradeV0(context.Background(), map[string]interface{}{"id": "unexpected"}, nil); err == nil {
+		t.Fatal("expected an error when the SDP ID can not be determined")
+	}
+}
+
+func TestResourceSilkVolumeStateUpgradeV1(t *testing.T) {
+
+	rawState := map[string]interface{}{
+		"id":           "12",
+		"host_mapping": []interface{}{"host01", "host02"},
+	}
+
+	upgraded, err := resourceSilkVolumeStateUpgradeV1(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedHostMapping := []interface{}{
+		map[string]interface{}{"name": "host01", "lun": 0},
+		map[string]interface{}{"name": "host02", "lun": 0},
+	}
+	if !reflect.DeepEqual(upgraded["host_mapping"], expectedHostMapping) {
+		t.Fatalf("expected host_mapping %v, got %v", expectedHostMapping, upgraded["host_mapping"])
+	}
+
+	if !reflect.DeepEqual(upgraded["host_group_mapping"], []interface{}{}) {
+		t.Fatalf("expected an empty host_group_mapping, got %v", upgraded["host_group_mapping"])
+	}
+}
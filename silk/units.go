@@ -0,0 +1,111 @@
+package silk
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The SDP stores Volume sizes and Volume Group quotas in KiB. The size and quota attributes accept a number followed
+// by a unit. The SI spellings (K, KB, M, MB, ...) are treated as their binary equivalents (KiB, MiB, ...) to match the
+// sizes shown in the UI and the existing size_in_gb and quota_in_gb attributes.
+
+// capacityUnits holds the number of KiB in each supported unit.
+var capacityUnits = map[string]float64{
+	"K":   1,
+	"KB":  1,
+	"KIB": 1,
+	"M":   1024,
+	"MB":  1024,
+	"MIB": 1024,
+	"G":   1024 * 1024,
+	"GB":  1024 * 1024,
+	"GIB": 1024 * 1024,
+	"T":   1024 * 1024 * 1024,
+	"TB":  1024 * 1024 * 1024,
+	"TIB": 1024 * 1024 * 1024,
+	"P":   1024 * 1024 * 1024 * 1024,
+	"PB":  1024 * 1024 * 1024 * 1024,
+	"PIB": 1024 * 1024 * 1024 * 1024,
+}
+
+var capacityRegex = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)\s*([A-Za-z]*)$`)
+
+// parseCapacity converts a capacity with a unit (ex. 500GiB, 2TiB, or 1.5T) to KiB. A value of 0 does not require a
+// unit.
+func parseCapacity(value string) (int, error) {
+
+	match := capacityRegex.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, fmt.Errorf("'%s' is not a valid capacity. Use a number followed by a unit (ex. 500GiB, 2TiB, or 1.5T)", value)
+	}
+
+	number, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if number == 0 {
+		return 0, nil
+	}
+
+	unit, ok := capacityUnits[strings.ToUpper(match[2])]
+	if !ok {
+		return 0, fmt.Errorf("'%s' does not include a valid unit. Supported units are K, M, G, T, and P, optionally followed by B or iB", value)
+	}
+
+	kib := number * unit
+	if kib != math.Trunc(kib) {
+		return 0, fmt.Errorf("'%s' is not a whole number of KiB", value)
+	}
+
+	return int(kib), nil
+}
+
+// formatCapacity converts a capacity in KiB to the largest binary unit that represents it exactly (ex. 1.5TiB is
+// returned as 1536GiB).
+func formatCapacity(kib int) string {
+
+	if kib == 0 {
+		return "0"
+	}
+
+	for _, unit := range []string{"PiB", "TiB", "GiB", "MiB"} {
+		size := int(capacityUnits[strings.ToUpper(unit)])
+		if kib%size == 0 {
+			return fmt.Sprintf("%d%s", kib/size, unit)
+		}
+	}
+
+	return fmt.Sprintf("%dKiB", kib)
+}
+
+// validateCapacity is a ValidateFunc for the capacity attributes.
+func validateCapacity(value interface{}, key string) ([]string, []error) {
+
+	if _, err := parseCapacity(value.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", key, err)}
+	}
+
+	return nil, nil
+}
+
+// diffSuppressCapacity prevents a diff between equivalent spellings of the same capacity (ex. 1TiB and 1024G).
+func diffSuppressCapacity(k, old, new string, d *schema.ResourceData) bool {
+
+	oldKiB, err := parseCapacity(old)
+	if err != nil {
+		return false
+	}
+
+	newKiB, err := parseCapacity(new)
+	if err != nil {
+		return false
+	}
+
+	return oldKiB == newKiB
+}
@@ -0,0 +1,69 @@
+package silk
+
+import (
+	"testing"
+)
+
+func TestParseCapacity(t *testing.T) {
+
+	testCases := map[string]int{
+		"500GiB": 500 * 1024 * 1024,
+		"2TiB":   2 * 1024 * 1024 * 1024,
+		"1.5T":   1536 * 1024 * 1024,
+		"512 mb": 512 * 1024,
+		"10G":    10 * 1024 * 1024,
+		"64KiB":  64,
+		"0":      0,
+	}
+
+	for value, expected := range testCases {
+		kib, err := parseCapacity(value)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", value, err)
+		}
+
+		if kib != expected {
+			t.Fatalf("%s: expected %d KiB, got %d", value, expected, kib)
+		}
+	}
+
+	for _, value := range []string{"", "10", "GiB", "10XB", "-1GiB", "0.1K"} {
+		if _, err := parseCapacity(value); err == nil {
+			t.Fatalf("%s: expected an error", value)
+		}
+	}
+}
+
+func TestFormatCapacity(t *testing.T) {
+
+	testCases := map[int]string{
+		0:                          "0",
+		64:                         "64KiB",
+		512 * 1024:                 "512MiB",
+		1536 * 1024 * 1024:         "1536GiB",
+		2 * 1024 * 1024 * 1024:     "2TiB",
+		1024 * 1024 * 1024 * 1024:  "1PiB",
+		1024*1024*1024*1024 + 1024: "1073741825MiB",
+	}
+
+	for kib, expected := range testCases {
+		if formatted := formatCapacity(kib); formatted != expected {
+			t.Fatalf("%d: expected '%s', got '%s'", kib, expected, formatted)
+		}
+	}
+}
+
+func TestDiffSuppressCapacity(t *testing.T) {
+
+	if !diffSuppressCapacity("size", "1TiB", "1024G", nil) {
+		t.Fatal("expected 1TiB and 1024G to be equivalent")
+	}
+
+	if diffSuppressCapacity("size", "1TiB", "1TB1", nil) {
+		t.Fatal("expected an invalid capacity to produce a diff")
+	}
+
+	if diffSuppressCapacity("size", "500GiB", "1TiB", nil) {
+		t.Fatal("expected 500GiB and 1TiB to produce a diff")
+	}
+}